@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
+	"github.com/example/k8s-scheduler-new/pkg/logging"
 	"github.com/example/k8s-scheduler-new/pkg/scheduler"
 	"k8s.io/klog/v2"
 )
@@ -13,14 +15,35 @@ func main() {
 	var kubeconfig string
 	var schedulerName string
 	var gangLabel string
-	var minAvailableAnnotation string
+	var profilePath string
+	var healthBindAddress string
+	var metricsBindAddress string
+	var leaderElect bool
+	var leaderElectNamespace string
+	var leaderElectName string
+	var leaderElectLeaseDuration time.Duration
+	var leaderElectRenewDeadline time.Duration
+	var leaderElectRetryPeriod time.Duration
+	logOpts := logging.NewOptions()
+	logOpts.AddFlags(flag.CommandLine)
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster")
 	flag.StringVar(&schedulerName, "scheduler-name", "batch-scheduler", "Name of the scheduler to watch for on pods")
-	flag.StringVar(&gangLabel, "gang-label", "batch.scheduling.k8s.io/gang", "Label key that identifies gang members")
-	flag.StringVar(&minAvailableAnnotation, "min-available-annotation", "batch.scheduling.k8s.io/min-available", "Annotation key that defines min available gang size")
-	klog.InitFlags(nil)
+	flag.StringVar(&gangLabel, "gang-label", "batch.scheduling.k8s.io/gang", "Legacy label key that identifies gang members when no PodGroup exists")
+	flag.StringVar(&profilePath, "config", "", "Path to a KubeSchedulerConfiguration-style YAML profile. When unset, the built-in default profile is used")
+	flag.StringVar(&healthBindAddress, "health-bind-address", ":10259", "Address to serve /healthz and /readyz on. Empty disables the HTTP server")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", ":10251", "Address to serve /metrics on. Empty disables the metrics server")
+	flag.BoolVar(&leaderElect, "leader-elect", false, "Enable leader election so multiple replicas can run active/passive")
+	flag.StringVar(&leaderElectNamespace, "leader-elect-resource-namespace", "kube-system", "Namespace of the Lease used for leader election")
+	flag.StringVar(&leaderElectName, "leader-elect-resource-name", "batch-scheduler", "Name of the Lease used for leader election")
+	flag.DurationVar(&leaderElectLeaseDuration, "leader-elect-lease-duration", 15*time.Second, "Duration non-leader candidates wait before forcing acquisition")
+	flag.DurationVar(&leaderElectRenewDeadline, "leader-elect-renew-deadline", 10*time.Second, "Duration the leader retries refreshing leadership before giving it up")
+	flag.DurationVar(&leaderElectRetryPeriod, "leader-elect-retry-period", 2*time.Second, "Duration clients wait between actions")
 	flag.Parse()
 
+	if err := logOpts.Apply(); err != nil {
+		klog.Fatalf("invalid logging configuration: %v", err)
+	}
+
 	ctx := klog.NewContext(context.Background(), klog.Background())
 
 	cfg, err := scheduler.BuildConfig(kubeconfig)
@@ -29,16 +52,26 @@ func main() {
 	}
 
 	batchScheduler, err := scheduler.New(ctx, cfg, scheduler.Options{
-		SchedulerName:          schedulerName,
-		GangLabel:              gangLabel,
-		MinAvailableAnnotation: minAvailableAnnotation,
+		SchedulerName:      schedulerName,
+		GangLabel:          gangLabel,
+		ProfilePath:        profilePath,
+		HealthBindAddress:  healthBindAddress,
+		MetricsBindAddress: metricsBindAddress,
+		LeaderElection: scheduler.LeaderElectionOptions{
+			Enabled:        leaderElect,
+			LeaseNamespace: leaderElectNamespace,
+			LeaseName:      leaderElectName,
+			LeaseDuration:  leaderElectLeaseDuration,
+			RenewDeadline:  leaderElectRenewDeadline,
+			RetryPeriod:    leaderElectRetryPeriod,
+		},
 	})
 	if err != nil {
 		klog.Fatalf("failed to construct scheduler: %v", err)
 	}
 
 	if err := batchScheduler.Run(ctx); err != nil {
-		klog.ErrorS(err, "scheduler exited with error")
+		klog.FromContext(ctx).Error(err, "scheduler exited with error")
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,82 @@
+// Package v1alpha1 contains the PodGroup API used for gang-scheduling.
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GroupName is the API group for the PodGroup CRD.
+const GroupName = "scheduling.example.io"
+
+// PodGroupLabel is set on member pods and resolved to the owning PodGroup name.
+const PodGroupLabel = GroupName + "/pod-group"
+
+// PodGroupPhase describes the lifecycle phase of a PodGroup.
+type PodGroupPhase string
+
+const (
+	// PodGroupPending means the PodGroup has been accepted but no member has started scheduling.
+	PodGroupPending PodGroupPhase = "Pending"
+	// PodGroupPreScheduling means the PodGroup is waiting for enough member pods to become schedulable.
+	PodGroupPreScheduling PodGroupPhase = "PreScheduling"
+	// PodGroupScheduling means MinMember pods are being bound.
+	PodGroupScheduling PodGroupPhase = "Scheduling"
+	// PodGroupScheduled means at least MinMember pods have been bound to nodes.
+	PodGroupScheduled PodGroupPhase = "Scheduled"
+	// PodGroupRunning means at least MinMember member pods are running or have succeeded.
+	PodGroupRunning PodGroupPhase = "Running"
+	// PodGroupFailed means a member pod has failed while fewer than MinMember pods are bound to nodes.
+	PodGroupFailed PodGroupPhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroup coordinates the gang-scheduling of a set of pods.
+type PodGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PodGroupSpec   `json:"spec,omitempty"`
+	Status PodGroupStatus `json:"status,omitempty"`
+}
+
+// PodGroupSpec defines the desired state of a PodGroup.
+type PodGroupSpec struct {
+	// MinMember is the minimum number of pods that must be scheduled together.
+	MinMember int32 `json:"minMember"`
+	// MinResources is the minimum total resources required before scheduling starts.
+	// +optional
+	MinResources v1.ResourceList `json:"minResources,omitempty"`
+	// Queue is the name of the queue this PodGroup should be scheduled through.
+	// +optional
+	Queue string `json:"queue,omitempty"`
+	// PriorityClassName is the priority class shared by member pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// PodGroupStatus holds the observed state of a PodGroup.
+type PodGroupStatus struct {
+	// Phase is the current lifecycle phase of the PodGroup.
+	Phase PodGroupPhase `json:"phase,omitempty"`
+	// Running is the number of member pods currently running.
+	Running int32 `json:"running,omitempty"`
+	// Succeeded is the number of member pods that completed successfully.
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of member pods that failed.
+	Failed int32 `json:"failed,omitempty"`
+	// ScheduleStartTime is when the PodGroup first entered the Scheduled phase.
+	// +optional
+	ScheduleStartTime *metav1.Time `json:"scheduleStartTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroupList is a list of PodGroups.
+type PodGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodGroup `json:"items"`
+}
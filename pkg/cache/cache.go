@@ -0,0 +1,195 @@
+// Package cache maintains an event-driven, per-node view of requested
+// resources so a scheduling cycle can read node state in O(1) per node
+// instead of relisting every pod in the cluster. It is kept in sync from
+// pod and node informer events and additionally tracks pods the scheduler
+// has just bound ("assumed") so their resources are reserved before the
+// informer has observed the binding.
+package cache
+
+import (
+	"sync"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Cache holds the current requested-resource totals and pod set for every
+// known node. All methods are safe for concurrent use.
+type Cache struct {
+	mu      sync.RWMutex
+	nodes   map[string]*nodeState
+	assumed map[types.UID]string // pod UID -> node name, for pods AssumePod reserved but no Add event has confirmed yet
+}
+
+type nodeState struct {
+	node      *v1.Node
+	requested framework.Resource
+	pods      map[types.UID]*v1.Pod
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{
+		nodes:   make(map[string]*nodeState),
+		assumed: make(map[types.UID]string),
+	}
+}
+
+// AddNode records node, creating or replacing its entry. Pod bookkeeping for
+// the node, if any, is preserved.
+func (c *Cache) AddNode(node *v1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodeState(node.Name).node = node
+}
+
+// RemoveNode drops node and every pod recorded against it.
+func (c *Cache) RemoveNode(node *v1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.nodes, node.Name)
+}
+
+// AddPod records pod as running on pod.Spec.NodeName, adding its resource
+// request to that node's total. It is a no-op if pod has no assigned node,
+// or if this pod's UID is already recorded (an informer resync, or an Add
+// event confirming a pod this cache already assumed).
+func (c *Cache) AddPod(pod *v1.Pod) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.assumed, pod.UID)
+
+	ns := c.nodeState(pod.Spec.NodeName)
+	if _, exists := ns.pods[pod.UID]; exists {
+		return
+	}
+	ns.pods[pod.UID] = pod
+	req := framework.PodRequest(pod)
+	ns.requested.MilliCPU += req.MilliCPU
+	ns.requested.Memory += req.Memory
+}
+
+// UpdatePod reconciles a pod update: a pod that has terminated or is being
+// deleted is removed from its node's bookkeeping, otherwise it is (re)added.
+func (c *Cache) UpdatePod(_, newPod *v1.Pod) {
+	if newPod.DeletionTimestamp != nil || isTerminal(newPod) {
+		c.RemovePod(newPod)
+		return
+	}
+	c.AddPod(newPod)
+}
+
+// RemovePod subtracts pod's resource request from its node's total and
+// forgets it. It is a no-op if the pod was never recorded.
+func (c *Cache) RemovePod(pod *v1.Pod) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.assumed, pod.UID)
+
+	ns, ok := c.nodes[pod.Spec.NodeName]
+	if !ok {
+		return
+	}
+	if _, exists := ns.pods[pod.UID]; !exists {
+		return
+	}
+	delete(ns.pods, pod.UID)
+	req := framework.PodRequest(pod)
+	ns.requested.MilliCPU -= req.MilliCPU
+	ns.requested.Memory -= req.Memory
+}
+
+// AssumePod reserves pod's resources on nodeName immediately after a bind
+// call returns, rather than waiting for the pod informer to observe the
+// binding. A later Add event for the same pod UID is a no-op against
+// already-reserved resources.
+func (c *Cache) AssumePod(pod *v1.Pod, nodeName string) {
+	assumed := pod.DeepCopy()
+	assumed.Spec.NodeName = nodeName
+	c.AddPod(assumed)
+	c.mu.Lock()
+	c.assumed[pod.UID] = nodeName
+	c.mu.Unlock()
+}
+
+// nodeState returns the bookkeeping entry for name, creating one if absent.
+// Callers must hold c.mu for writing.
+func (c *Cache) nodeState(name string) *nodeState {
+	ns, ok := c.nodes[name]
+	if !ok {
+		ns = &nodeState{pods: make(map[types.UID]*v1.Pod)}
+		c.nodes[name] = ns
+	}
+	return ns
+}
+
+// Snapshot takes a copy-on-write view of every node the cache knows about,
+// suitable for framework.NodeInfoLister. Nodes the cache has only heard
+// about through a pod (but whose Node object hasn't been observed yet) are
+// omitted.
+func (c *Cache) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]*framework.NodeInfo, 0, len(c.nodes))
+	byName := make(map[string]*framework.NodeInfo, len(c.nodes))
+	for name, ns := range c.nodes {
+		if ns.node == nil {
+			continue
+		}
+		pods := make([]*v1.Pod, 0, len(ns.pods))
+		for _, p := range ns.pods {
+			pods = append(pods, p)
+		}
+		ni := &framework.NodeInfo{
+			Node: ns.node,
+			Pods: pods,
+			Allocatable: framework.Resource{
+				MilliCPU: ns.node.Status.Allocatable.Cpu().MilliValue(),
+				Memory:   ns.node.Status.Allocatable.Memory().Value(),
+			},
+			Requested: ns.requested,
+		}
+		infos = append(infos, ni)
+		byName[name] = ni
+	}
+	return &Snapshot{nodes: infos, byName: byName}
+}
+
+// Snapshot is an immutable, point-in-time view of every node's resource
+// state, safe to read from multiple goroutines without locking.
+type Snapshot struct {
+	nodes  []*framework.NodeInfo
+	byName map[string]*framework.NodeInfo
+}
+
+var _ framework.NodeInfoLister = (*Snapshot)(nil)
+
+// List implements framework.NodeInfoLister.
+func (s *Snapshot) List() ([]*framework.NodeInfo, error) {
+	return s.nodes, nil
+}
+
+// Get implements framework.NodeInfoLister.
+func (s *Snapshot) Get(nodeName string) (*framework.NodeInfo, error) {
+	ni, ok := s.byName[nodeName]
+	if !ok {
+		return nil, nodeNotFoundError(nodeName)
+	}
+	return ni, nil
+}
+
+type nodeNotFoundError string
+
+func (e nodeNotFoundError) Error() string { return "node " + string(e) + " not found" }
+
+func isTerminal(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+}
@@ -0,0 +1,139 @@
+package framework
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Profile configures which plugins run at each extension point, modeled
+// after upstream kube-scheduler's KubeSchedulerConfiguration profiles.
+type Profile struct {
+	SchedulerName string      `json:"schedulerName,omitempty"`
+	Plugins       PluginsList `json:"plugins,omitempty"`
+}
+
+// PluginsList holds the PluginSet enabled at each extension point.
+type PluginsList struct {
+	PreFilter PluginSet `json:"preFilter,omitempty"`
+	Filter    PluginSet `json:"filter,omitempty"`
+	Score     PluginSet `json:"score,omitempty"`
+	Reserve   PluginSet `json:"reserve,omitempty"`
+	Permit    PluginSet `json:"permit,omitempty"`
+	PreBind   PluginSet `json:"preBind,omitempty"`
+	Bind      PluginSet `json:"bind,omitempty"`
+}
+
+// PluginSet is the set of plugins enabled, in order, at one extension point,
+// plus any plugins to subtract from the default set when merging a
+// user-supplied profile over DefaultProfile; see MergeProfile.
+type PluginSet struct {
+	Enabled  []PluginRef `json:"enabled,omitempty"`
+	Disabled []PluginRef `json:"disabled,omitempty"`
+}
+
+// mergePluginSet appends override's Enabled plugins to base's (skipping
+// names base already lists), then drops any plugin override names in
+// Disabled, anywhere in the result.
+func mergePluginSet(base, override PluginSet) PluginSet {
+	merged := PluginSet{Enabled: append([]PluginRef{}, base.Enabled...)}
+	present := make(map[string]bool, len(merged.Enabled))
+	for _, ref := range merged.Enabled {
+		present[ref.Name] = true
+	}
+	for _, ref := range override.Enabled {
+		if present[ref.Name] {
+			continue
+		}
+		merged.Enabled = append(merged.Enabled, ref)
+		present[ref.Name] = true
+	}
+
+	if len(override.Disabled) == 0 {
+		return merged
+	}
+	disabled := make(map[string]bool, len(override.Disabled))
+	for _, ref := range override.Disabled {
+		disabled[ref.Name] = true
+	}
+	filtered := merged.Enabled[:0]
+	for _, ref := range merged.Enabled {
+		if !disabled[ref.Name] {
+			filtered = append(filtered, ref)
+		}
+	}
+	merged.Enabled = filtered
+	return merged
+}
+
+// PluginRef names a plugin and, for Score plugins, its weight.
+type PluginRef struct {
+	Name   string `json:"name"`
+	Weight int32  `json:"weight,omitempty"`
+}
+
+// LoadProfile reads a KubeSchedulerConfiguration-style YAML file from path.
+// The result is an override to be combined with DefaultProfile via
+// MergeProfile; it is not a complete profile on its own.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profile %s: %w", path, err)
+	}
+	profile := &Profile{}
+	if err := yaml.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("parse profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// MergeProfile layers override onto base: at each extension point, override's
+// Enabled plugins are appended after base's (skipping duplicates by name),
+// then anything override lists in Disabled is subtracted from the combined
+// set. override.SchedulerName replaces base's when set. Use this to combine
+// a user-supplied profile (from LoadProfile) with DefaultProfile, so a
+// profile file only has to name what it wants to add or remove.
+func MergeProfile(base, override *Profile) *Profile {
+	merged := &Profile{SchedulerName: base.SchedulerName}
+	if override.SchedulerName != "" {
+		merged.SchedulerName = override.SchedulerName
+	}
+	merged.Plugins = PluginsList{
+		PreFilter: mergePluginSet(base.Plugins.PreFilter, override.Plugins.PreFilter),
+		Filter:    mergePluginSet(base.Plugins.Filter, override.Plugins.Filter),
+		Score:     mergePluginSet(base.Plugins.Score, override.Plugins.Score),
+		Reserve:   mergePluginSet(base.Plugins.Reserve, override.Plugins.Reserve),
+		Permit:    mergePluginSet(base.Plugins.Permit, override.Plugins.Permit),
+		PreBind:   mergePluginSet(base.Plugins.PreBind, override.Plugins.PreBind),
+		Bind:      mergePluginSet(base.Plugins.Bind, override.Plugins.Bind),
+	}
+	return merged
+}
+
+// DefaultProfile returns the built-in plugin ordering used when no
+// --config file is supplied: resource fit, basic node health, taints and
+// inter-pod affinity as filters, resource fit and topology spread as scores,
+// and gang coscheduling gating bind through Permit.
+func DefaultProfile(schedulerName string) *Profile {
+	return &Profile{
+		SchedulerName: schedulerName,
+		Plugins: PluginsList{
+			PreFilter: PluginSet{Enabled: []PluginRef{{Name: "Coscheduling"}}},
+			Filter: PluginSet{Enabled: []PluginRef{
+				{Name: "NodeUnschedulable"},
+				{Name: "NodeReady"},
+				{Name: "TaintToleration"},
+				{Name: "NodeResourcesFit"},
+				{Name: "InterPodAffinity"},
+			}},
+			Score: PluginSet{Enabled: []PluginRef{
+				{Name: "NodeResourcesFit", Weight: 1},
+				{Name: "PodTopologySpread", Weight: 2},
+			}},
+			Reserve: PluginSet{Enabled: []PluginRef{{Name: "NodeResourcesFit"}, {Name: "Coscheduling"}}},
+			Permit:  PluginSet{Enabled: []PluginRef{{Name: "Coscheduling"}}},
+			Bind:    PluginSet{Enabled: []PluginRef{{Name: "DefaultBinder"}}},
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Framework runs the ordered set of plugins enabled by a Profile against a
+// scheduling cycle.
+type Framework struct {
+	clientSet    kubernetes.Interface
+	nodeInfos    NodeInfoLister
+	gangResolver GangResolver
+	preFilter    []PreFilterPlugin
+	filter       []FilterPlugin
+	score        []scorePluginWithWeight
+	reserve      []ReservePlugin
+	permit       []PermitPlugin
+	preBind      []PreBindPlugin
+	bind         []BindPlugin
+}
+
+type scorePluginWithWeight struct {
+	ScorePlugin
+	weight int32
+}
+
+var _ Handle = (*Framework)(nil)
+
+// ClientSet implements Handle.
+func (f *Framework) ClientSet() kubernetes.Interface { return f.clientSet }
+
+// NodeInfos implements Handle.
+func (f *Framework) NodeInfos() NodeInfoLister { return f.nodeInfos }
+
+// GangResolver implements Handle.
+func (f *Framework) GangResolver() GangResolver { return f.gangResolver }
+
+// RunPreFilterPlugins runs every enabled PreFilter plugin in order, stopping
+// at the first rejection.
+func (f *Framework) RunPreFilterPlugins(ctx context.Context, state *CycleState, pod *v1.Pod) *Status {
+	for _, p := range f.preFilter {
+		if status := p.PreFilter(ctx, state, pod); !status.IsSuccess() {
+			return status.WithPlugin(p.Name())
+		}
+	}
+	return nil
+}
+
+// RunFilterPlugins runs every enabled Filter plugin against a single node,
+// stopping at the first rejection.
+func (f *Framework) RunFilterPlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeInfo *NodeInfo) *Status {
+	for _, p := range f.filter {
+		if status := p.Filter(ctx, state, pod, nodeInfo); !status.IsSuccess() {
+			return status.WithPlugin(p.Name())
+		}
+	}
+	return nil
+}
+
+// RunScorePlugins scores every feasible node and returns the combined,
+// weighted total per node name.
+func (f *Framework) RunScorePlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeInfos []*NodeInfo) (map[string]int64, *Status) {
+	totals := make(map[string]int64, len(nodeInfos))
+	for _, sp := range f.score {
+		raw := make(map[string]int64, len(nodeInfos))
+		for _, ni := range nodeInfos {
+			s, status := sp.Score(ctx, state, pod, ni)
+			if !status.IsSuccess() {
+				return nil, status.WithPlugin(sp.Name())
+			}
+			raw[ni.Node.Name] = s
+		}
+		if ext, ok := sp.ScorePlugin.(ScoreExtensions); ok {
+			if status := ext.NormalizeScore(ctx, state, pod, raw); !status.IsSuccess() {
+				return nil, status.WithPlugin(sp.Name())
+			}
+		}
+		for name, s := range raw {
+			totals[name] += s * int64(sp.weight)
+		}
+	}
+	return totals, nil
+}
+
+// RunReservePlugins notifies every enabled Reserve plugin that nodeName was
+// chosen for pod, unwinding already-reserved plugins if one rejects.
+func (f *Framework) RunReservePlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status {
+	for i, p := range f.reserve {
+		if status := p.Reserve(ctx, state, pod, nodeName); !status.IsSuccess() {
+			for j := i - 1; j >= 0; j-- {
+				f.reserve[j].Unreserve(ctx, state, pod, nodeName)
+			}
+			return status.WithPlugin(p.Name())
+		}
+	}
+	return nil
+}
+
+// RunUnreservePlugins rolls back every Reserve plugin for pod/nodeName, e.g.
+// after a later extension point fails.
+func (f *Framework) RunUnreservePlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) {
+	for _, p := range f.reserve {
+		p.Unreserve(ctx, state, pod, nodeName)
+	}
+}
+
+// RunPermitPlugins runs every enabled Permit plugin in order. When a plugin
+// returns Wait, RunPermitPlugins blocks (polling that plugin) until it
+// reports success, rejects, or its own requested wait duration (or
+// DefaultWaitTimeout, if it gave none) elapses, before moving on to the next.
+func (f *Framework) RunPermitPlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status {
+	for _, p := range f.permit {
+		status, timeout := p.Permit(ctx, state, pod, nodeName)
+		if status.IsSuccess() {
+			continue
+		}
+		if status.Code() != Wait {
+			return status.WithPlugin(p.Name())
+		}
+		if timeout <= 0 {
+			timeout = DefaultWaitTimeout
+		}
+		if waited := f.waitForPermit(ctx, p, state, pod, nodeName, timeout); !waited.IsSuccess() {
+			return waited.WithPlugin(p.Name())
+		}
+	}
+	return nil
+}
+
+func (f *Framework) waitForPermit(ctx context.Context, p PermitPlugin, state *CycleState, pod *v1.Pod, nodeName string, timeout time.Duration) *Status {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return AsStatus(ctx.Err())
+		case <-deadline.C:
+			return NewStatus(Unschedulable, fmt.Sprintf("timed out waiting on permit plugin %s", p.Name()))
+		case <-ticker.C:
+			status, _ := p.Permit(ctx, state, pod, nodeName)
+			if status.IsSuccess() {
+				return nil
+			}
+			if status.Code() != Wait {
+				return status
+			}
+		}
+	}
+}
+
+// RunPreBindPlugins runs every enabled PreBind plugin in order.
+func (f *Framework) RunPreBindPlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status {
+	for _, p := range f.preBind {
+		if status := p.PreBind(ctx, state, pod, nodeName); !status.IsSuccess() {
+			return status.WithPlugin(p.Name())
+		}
+	}
+	return nil
+}
+
+// RunBindPlugins runs enabled Bind plugins until one handles the pod.
+func (f *Framework) RunBindPlugins(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status {
+	if len(f.bind) == 0 {
+		return NewStatus(Error, "no bind plugin configured")
+	}
+	var status *Status
+	for _, p := range f.bind {
+		status = p.Bind(ctx, state, pod, nodeName)
+		if status.IsSuccess() {
+			return nil
+		}
+	}
+	return status.WithPlugin("bind")
+}
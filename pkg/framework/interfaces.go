@@ -0,0 +1,96 @@
+package framework
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Plugin is implemented by every scheduling plugin; Name must match the
+// name used to enable/disable/order it in a scheduler profile.
+type Plugin interface {
+	Name() string
+}
+
+// PreFilterPlugin runs once per pod before Filter is run against any node.
+// It may reject the pod outright (e.g. a gang that has no chance of
+// reaching MinMember) or stash data in CycleState for later plugins.
+type PreFilterPlugin interface {
+	Plugin
+	PreFilter(ctx context.Context, state *CycleState, pod *v1.Pod) *Status
+}
+
+// FilterPlugin decides whether pod may be placed on the node described by
+// nodeInfo.
+type FilterPlugin interface {
+	Plugin
+	Filter(ctx context.Context, state *CycleState, pod *v1.Pod, nodeInfo *NodeInfo) *Status
+}
+
+// ScorePlugin ranks a node that passed every Filter. Higher is better.
+type ScorePlugin interface {
+	Plugin
+	Score(ctx context.Context, state *CycleState, pod *v1.Pod, nodeInfo *NodeInfo) (int64, *Status)
+}
+
+// ScoreExtensions lets a ScorePlugin normalize its raw scores across all
+// nodes (e.g. min-max scaling to 0-100) once every node has been scored.
+type ScoreExtensions interface {
+	NormalizeScore(ctx context.Context, state *CycleState, pod *v1.Pod, scores map[string]int64) *Status
+}
+
+// ReservePlugin is notified when a node has been tentatively chosen for pod,
+// so it can reserve any plugin-owned state (e.g. gang bookkeeping) before
+// Permit/Bind run. Unreserve undoes it if a later step fails.
+type ReservePlugin interface {
+	Plugin
+	Reserve(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status
+	Unreserve(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string)
+}
+
+// PermitPlugin runs after Reserve and may approve, reject, or ask the
+// framework to Wait (e.g. until enough gang members have reserved nodes).
+// A non-zero duration alongside a Wait status tells the framework how long
+// to block before treating the wait as timed out.
+type PermitPlugin interface {
+	Plugin
+	Permit(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) (*Status, time.Duration)
+}
+
+// PreBindPlugin runs immediately before Bind, e.g. to create objects the pod
+// depends on once a node is final.
+type PreBindPlugin interface {
+	Plugin
+	PreBind(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status
+}
+
+// BindPlugin performs the actual binding of pod to nodeName. Enabled
+// BindPlugins are tried in order until one returns a success status (nil or
+// code Success); a plugin that fails passes the pod on to the next one.
+type BindPlugin interface {
+	Plugin
+	Bind(ctx context.Context, state *CycleState, pod *v1.Pod, nodeName string) *Status
+}
+
+// Handle is the interface plugins use to reach shared scheduler state.
+type Handle interface {
+	ClientSet() kubernetes.Interface
+	NodeInfos() NodeInfoLister
+	GangResolver() GangResolver
+}
+
+// GangResolver answers which pods belong to the same gang as pod and how
+// many of them must be scheduled together, so the Coscheduling plugin does
+// not need to know how gangs are represented (PodGroup CR, label, or a bare
+// pod acting as its own gang of one).
+type GangResolver interface {
+	ResolveGang(pod *v1.Pod) (gangID string, minMember int, members []*v1.Pod, err error)
+}
+
+// NodeInfoLister gives plugins read access to the current node snapshot.
+type NodeInfoLister interface {
+	List() ([]*NodeInfo, error)
+	Get(nodeName string) (*NodeInfo, error)
+}
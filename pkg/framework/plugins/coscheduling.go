@@ -0,0 +1,94 @@
+package plugins
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+)
+
+// CoschedulingName is the registered name of the Coscheduling plugin.
+const CoschedulingName = "Coscheduling"
+
+const gangStateKey framework.StateKey = "Coscheduling/gang"
+
+type gangState struct {
+	gangID    string
+	minMember int
+}
+
+// Coscheduling implements gang scheduling: PreFilter rejects a pod whose
+// gang cannot possibly reach MinMember yet, and Permit holds each gang
+// member's binding until MinMember members have reserved a node, so a
+// partially-schedulable gang never binds only some of its pods.
+type Coscheduling struct {
+	handle framework.Handle
+
+	mu           sync.Mutex
+	reservations map[string]map[string]bool // gangID -> pod name -> reserved
+}
+
+// NewCoscheduling constructs the Coscheduling plugin.
+func NewCoscheduling(h framework.Handle) (framework.Plugin, error) {
+	return &Coscheduling{handle: h, reservations: make(map[string]map[string]bool)}, nil
+}
+
+// Name implements framework.Plugin.
+func (c *Coscheduling) Name() string { return CoschedulingName }
+
+// PreFilter implements framework.PreFilterPlugin.
+func (c *Coscheduling) PreFilter(_ context.Context, state *framework.CycleState, pod *v1.Pod) *framework.Status {
+	gangID, minMember, members, err := c.handle.GangResolver().ResolveGang(pod)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if len(members) < minMember {
+		return framework.NewStatus(framework.Unschedulable, "gang has not assembled MinMember pods yet")
+	}
+	state.Write(gangStateKey, gangState{gangID: gangID, minMember: minMember})
+	return nil
+}
+
+// Permit implements framework.PermitPlugin.
+func (c *Coscheduling) Permit(_ context.Context, state *framework.CycleState, pod *v1.Pod, _ string) (*framework.Status, time.Duration) {
+	val, ok := state.Read(gangStateKey)
+	if !ok {
+		return nil, 0
+	}
+	gs := val.(gangState)
+
+	c.mu.Lock()
+	reserved := c.reservations[gs.gangID]
+	if reserved == nil {
+		reserved = make(map[string]bool)
+		c.reservations[gs.gangID] = reserved
+	}
+	reserved[pod.Name] = true
+	count := len(reserved)
+	c.mu.Unlock()
+
+	if count >= gs.minMember {
+		return nil, 0
+	}
+	return framework.NewStatus(framework.Wait, "waiting for gang members to reserve nodes"), framework.DefaultWaitTimeout
+}
+
+// Reserve implements framework.ReservePlugin so a failed later step can
+// release this pod's gang reservation.
+func (c *Coscheduling) Reserve(context.Context, *framework.CycleState, *v1.Pod, string) *framework.Status {
+	return nil
+}
+
+// Unreserve implements framework.ReservePlugin.
+func (c *Coscheduling) Unreserve(_ context.Context, state *framework.CycleState, pod *v1.Pod, _ string) {
+	val, ok := state.Read(gangStateKey)
+	if !ok {
+		return
+	}
+	gs := val.(gangState)
+	c.mu.Lock()
+	delete(c.reservations[gs.gangID], pod.Name)
+	c.mu.Unlock()
+}
@@ -0,0 +1,37 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultBinderName is the registered name of the DefaultBinder plugin.
+const DefaultBinderName = "DefaultBinder"
+
+// DefaultBinder binds a pod to a node via the core v1 Binding subresource.
+type DefaultBinder struct {
+	handle framework.Handle
+}
+
+// NewDefaultBinder constructs the DefaultBinder plugin.
+func NewDefaultBinder(h framework.Handle) (framework.Plugin, error) {
+	return &DefaultBinder{handle: h}, nil
+}
+
+// Name implements framework.Plugin.
+func (b *DefaultBinder) Name() string { return DefaultBinderName }
+
+// Bind implements framework.BindPlugin.
+func (b *DefaultBinder) Bind(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	binding := &v1.Binding{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace, UID: pod.UID},
+		Target:     v1.ObjectReference{Kind: "Node", Name: nodeName},
+	}
+	if err := b.handle.ClientSet().CoreV1().Pods(pod.Namespace).Bind(ctx, binding, metav1.CreateOptions{}); err != nil {
+		return framework.AsStatus(err)
+	}
+	return nil
+}
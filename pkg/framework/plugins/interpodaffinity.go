@@ -0,0 +1,77 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// InterPodAffinityName is the registered name of the InterPodAffinity plugin.
+const InterPodAffinityName = "InterPodAffinity"
+
+// InterPodAffinity enforces pod.Spec.Affinity.PodAffinity/PodAntiAffinity
+// required-during-scheduling terms. Preferred terms are not scored.
+type InterPodAffinity struct {
+	handle framework.Handle
+}
+
+// NewInterPodAffinity constructs the InterPodAffinity plugin.
+func NewInterPodAffinity(h framework.Handle) (framework.Plugin, error) {
+	return &InterPodAffinity{handle: h}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *InterPodAffinity) Name() string { return InterPodAffinityName }
+
+// Filter implements framework.FilterPlugin.
+func (p *InterPodAffinity) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if pod.Spec.Affinity == nil {
+		return nil
+	}
+	nodeInfos, err := p.handle.NodeInfos().List()
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if aff := pod.Spec.Affinity.PodAffinity; aff != nil {
+		for _, term := range aff.RequiredDuringSchedulingIgnoredDuringExecution {
+			if !domainHasMatch(nodeInfos, nodeInfo, term) {
+				return framework.NewStatus(framework.Unschedulable, "required pod affinity not satisfied on "+term.TopologyKey)
+			}
+		}
+	}
+	if aff := pod.Spec.Affinity.PodAntiAffinity; aff != nil {
+		for _, term := range aff.RequiredDuringSchedulingIgnoredDuringExecution {
+			if domainHasMatch(nodeInfos, nodeInfo, term) {
+				return framework.NewStatus(framework.Unschedulable, "required pod anti-affinity violated on "+term.TopologyKey)
+			}
+		}
+	}
+	return nil
+}
+
+// domainHasMatch reports whether any pod matching term's selector already
+// sits on a node sharing nodeInfo's value of term.TopologyKey.
+func domainHasMatch(nodeInfos []*framework.NodeInfo, nodeInfo *framework.NodeInfo, term v1.PodAffinityTerm) bool {
+	domain, ok := nodeInfo.Node.Labels[term.TopologyKey]
+	if !ok {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return false
+	}
+	for _, ni := range nodeInfos {
+		if ni.Node.Labels[term.TopologyKey] != domain {
+			continue
+		}
+		for _, pod := range ni.Pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeReadyName is the registered name of the NodeReady plugin.
+const NodeReadyName = "NodeReady"
+
+// NodeReady filters out nodes whose Ready condition is not True.
+type NodeReady struct{}
+
+// NewNodeReady constructs the NodeReady plugin.
+func NewNodeReady(framework.Handle) (framework.Plugin, error) {
+	return &NodeReady{}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *NodeReady) Name() string { return NodeReadyName }
+
+// Filter implements framework.FilterPlugin.
+func (p *NodeReady) Filter(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	for _, cond := range nodeInfo.Node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			if cond.Status == v1.ConditionTrue {
+				return nil
+			}
+			break
+		}
+	}
+	return framework.NewStatus(framework.Unschedulable, "node is not ready")
+}
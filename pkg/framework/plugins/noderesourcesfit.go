@@ -0,0 +1,59 @@
+// Package plugins contains the built-in framework.Plugin implementations
+// shipped with the batch scheduler.
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeResourcesFitName is the registered name of the NodeResourcesFit plugin.
+const NodeResourcesFitName = "NodeResourcesFit"
+
+// NodeResourcesFit filters out nodes that cannot fit a pod's resource
+// requests and scores the remaining nodes by how much headroom they have
+// left afterwards (more headroom scores lower, favoring bin-packing).
+type NodeResourcesFit struct{}
+
+// NewNodeResourcesFit constructs the NodeResourcesFit plugin.
+func NewNodeResourcesFit(framework.Handle) (framework.Plugin, error) {
+	return &NodeResourcesFit{}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *NodeResourcesFit) Name() string { return NodeResourcesFitName }
+
+// Filter implements framework.FilterPlugin.
+func (p *NodeResourcesFit) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if !nodeInfo.Available().Fits(framework.PodRequest(pod)) {
+		return framework.NewStatus(framework.Unschedulable, "insufficient cpu/memory")
+	}
+	return nil
+}
+
+// Score implements framework.ScorePlugin. Lower remaining headroom scores
+// higher so the scheduler favors packing nodes tightly.
+func (p *NodeResourcesFit) Score(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) (int64, *framework.Status) {
+	remaining := nodeInfo.Available().Sub(framework.PodRequest(pod))
+	if remaining.MilliCPU < 0 || remaining.Memory < 0 {
+		return 0, framework.NewStatus(framework.Unschedulable, "insufficient cpu/memory")
+	}
+	if nodeInfo.Allocatable.MilliCPU == 0 {
+		return 0, nil
+	}
+	// Score is the inverse of the fraction of CPU left free, scaled to 0-100.
+	fractionFree := remaining.MilliCPU * 100 / nodeInfo.Allocatable.MilliCPU
+	return 100 - fractionFree, nil
+}
+
+// Reserve implements framework.ReservePlugin. It keeps no state of its own;
+// the pod's resources on nodeName are consumed for the rest of this
+// scheduling cycle by the caller.
+func (p *NodeResourcesFit) Reserve(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	return nil // actual consumption is tracked by the caller via NodeInfo.AddPod
+}
+
+// Unreserve implements framework.ReservePlugin.
+func (p *NodeResourcesFit) Unreserve(context.Context, *framework.CycleState, *v1.Pod, string) {}
@@ -0,0 +1,31 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeUnschedulableName is the registered name of the NodeUnschedulable plugin.
+const NodeUnschedulableName = "NodeUnschedulable"
+
+// NodeUnschedulable filters out nodes marked unschedulable (e.g. cordoned
+// for maintenance via kubectl cordon).
+type NodeUnschedulable struct{}
+
+// NewNodeUnschedulable constructs the NodeUnschedulable plugin.
+func NewNodeUnschedulable(framework.Handle) (framework.Plugin, error) {
+	return &NodeUnschedulable{}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *NodeUnschedulable) Name() string { return NodeUnschedulableName }
+
+// Filter implements framework.FilterPlugin.
+func (p *NodeUnschedulable) Filter(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if nodeInfo.Node.Spec.Unschedulable {
+		return framework.NewStatus(framework.Unschedulable, "node is marked unschedulable")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodTopologySpreadName is the registered name of the PodTopologySpread plugin.
+const PodTopologySpreadName = "PodTopologySpread"
+
+// PodTopologySpread enforces pod.Spec.TopologySpreadConstraints by counting,
+// per topology domain, how many matching pods already sit on nodes sharing
+// it, and rejecting or down-scoring nodes that would push the skew too high.
+type PodTopologySpread struct {
+	handle framework.Handle
+}
+
+// NewPodTopologySpread constructs the PodTopologySpread plugin.
+func NewPodTopologySpread(h framework.Handle) (framework.Plugin, error) {
+	return &PodTopologySpread{handle: h}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *PodTopologySpread) Name() string { return PodTopologySpreadName }
+
+// Filter implements framework.FilterPlugin.
+func (p *PodTopologySpread) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if len(pod.Spec.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	nodeInfos, err := p.handle.NodeInfos().List()
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	for _, c := range pod.Spec.TopologySpreadConstraints {
+		domain, ok := nodeInfo.Node.Labels[c.TopologyKey]
+		if !ok {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+		if err != nil {
+			return framework.AsStatus(err)
+		}
+		counts := domainCounts(nodeInfos, c.TopologyKey, selector)
+		projected := counts
+		projected[domain]++
+		if skew(projected) > int(c.MaxSkew) && c.WhenUnsatisfiable == v1.DoNotSchedule {
+			return framework.NewStatus(framework.Unschedulable, "would violate topology spread constraint on "+c.TopologyKey)
+		}
+	}
+	return nil
+}
+
+// Score implements framework.ScorePlugin, favoring nodes in the topology
+// domain with the fewest matching pods so far (spreading pods out).
+func (p *PodTopologySpread) Score(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) (int64, *framework.Status) {
+	if len(pod.Spec.TopologySpreadConstraints) == 0 {
+		return 0, nil
+	}
+	nodeInfos, err := p.handle.NodeInfos().List()
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+	var total int64
+	for _, c := range pod.Spec.TopologySpreadConstraints {
+		domain, ok := nodeInfo.Node.Labels[c.TopologyKey]
+		if !ok {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+		if err != nil {
+			return 0, framework.AsStatus(err)
+		}
+		counts := domainCounts(nodeInfos, c.TopologyKey, selector)
+		total -= int64(counts[domain])
+	}
+	return total, nil
+}
+
+// domainCounts tallies, per value of topologyKey, how many pods matching
+// selector currently sit on nodes carrying that label.
+func domainCounts(nodeInfos []*framework.NodeInfo, topologyKey string, selector labels.Selector) map[string]int {
+	counts := make(map[string]int)
+	for _, ni := range nodeInfos {
+		domain, ok := ni.Node.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+		if _, seen := counts[domain]; !seen {
+			counts[domain] = 0
+		}
+		for _, pod := range ni.Pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				counts[domain]++
+			}
+		}
+	}
+	return counts
+}
+
+func skew(counts map[string]int) int {
+	if len(counts) == 0 {
+		return 0
+	}
+	min, max := -1, -1
+	for _, c := range counts {
+		if min == -1 || c < min {
+			min = c
+		}
+		if max == -1 || c > max {
+			max = c
+		}
+	}
+	return max - min
+}
@@ -0,0 +1,18 @@
+package plugins
+
+import "github.com/example/k8s-scheduler-new/pkg/framework"
+
+// NewDefaultRegistry returns a framework.Registry containing every built-in
+// plugin shipped with the batch scheduler.
+func NewDefaultRegistry() framework.Registry {
+	return framework.Registry{
+		NodeResourcesFitName:  NewNodeResourcesFit,
+		NodeUnschedulableName: NewNodeUnschedulable,
+		NodeReadyName:         NewNodeReady,
+		TaintTolerationName:   NewTaintToleration,
+		PodTopologySpreadName: NewPodTopologySpread,
+		InterPodAffinityName:  NewInterPodAffinity,
+		CoschedulingName:      NewCoscheduling,
+		DefaultBinderName:     NewDefaultBinder,
+	}
+}
@@ -0,0 +1,45 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+)
+
+// TaintTolerationName is the registered name of the TaintToleration plugin.
+const TaintTolerationName = "TaintToleration"
+
+// TaintToleration filters out nodes whose NoSchedule/NoExecute taints the
+// pod does not tolerate.
+type TaintToleration struct{}
+
+// NewTaintToleration constructs the TaintToleration plugin.
+func NewTaintToleration(framework.Handle) (framework.Plugin, error) {
+	return &TaintToleration{}, nil
+}
+
+// Name implements framework.Plugin.
+func (p *TaintToleration) Name() string { return TaintTolerationName }
+
+// Filter implements framework.FilterPlugin.
+func (p *TaintToleration) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	for _, taint := range nodeInfo.Node.Spec.Taints {
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		if !tolerates(pod.Spec.Tolerations, taint) {
+			return framework.NewStatus(framework.Unschedulable, "untolerated taint "+taint.Key+"="+taint.Value+":"+string(taint.Effect))
+		}
+	}
+	return nil
+}
+
+func tolerates(tolerations []v1.Toleration, taint v1.Taint) bool {
+	for _, t := range tolerations {
+		if t.ToleratesTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package framework
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// PluginFactory constructs a plugin instance bound to the given Handle.
+type PluginFactory func(handle Handle) (Plugin, error)
+
+// Registry maps a plugin name, as used in a Profile, to its factory.
+type Registry map[string]PluginFactory
+
+// NewFramework builds a Framework by instantiating every plugin the profile
+// enables and sorting it into the extension points it implements.
+func NewFramework(registry Registry, profile *Profile, clientSet kubernetes.Interface, nodeInfos NodeInfoLister, gangResolver GangResolver) (*Framework, error) {
+	f := &Framework{clientSet: clientSet, nodeInfos: nodeInfos, gangResolver: gangResolver}
+	plugins := make(map[string]Plugin, len(registry))
+
+	register := func(set PluginSet) error {
+		for _, ref := range set.Enabled {
+			if plugins[ref.Name] != nil {
+				continue
+			}
+			factory, ok := registry[ref.Name]
+			if !ok {
+				return fmt.Errorf("unknown plugin %q", ref.Name)
+			}
+			p, err := factory(f)
+			if err != nil {
+				return fmt.Errorf("construct plugin %q: %w", ref.Name, err)
+			}
+			plugins[ref.Name] = p
+		}
+		return nil
+	}
+
+	for _, set := range []PluginSet{
+		profile.Plugins.PreFilter, profile.Plugins.Filter, profile.Plugins.Score,
+		profile.Plugins.Reserve, profile.Plugins.Permit, profile.Plugins.PreBind, profile.Plugins.Bind,
+	} {
+		if err := register(set); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ref := range profile.Plugins.PreFilter.Enabled {
+		p, ok := plugins[ref.Name].(PreFilterPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement PreFilterPlugin", ref.Name)
+		}
+		f.preFilter = append(f.preFilter, p)
+	}
+	for _, ref := range profile.Plugins.Filter.Enabled {
+		p, ok := plugins[ref.Name].(FilterPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement FilterPlugin", ref.Name)
+		}
+		f.filter = append(f.filter, p)
+	}
+	for _, ref := range profile.Plugins.Score.Enabled {
+		p, ok := plugins[ref.Name].(ScorePlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement ScorePlugin", ref.Name)
+		}
+		weight := ref.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		f.score = append(f.score, scorePluginWithWeight{ScorePlugin: p, weight: weight})
+	}
+	for _, ref := range profile.Plugins.Reserve.Enabled {
+		p, ok := plugins[ref.Name].(ReservePlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement ReservePlugin", ref.Name)
+		}
+		f.reserve = append(f.reserve, p)
+	}
+	for _, ref := range profile.Plugins.Permit.Enabled {
+		p, ok := plugins[ref.Name].(PermitPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement PermitPlugin", ref.Name)
+		}
+		f.permit = append(f.permit, p)
+	}
+	for _, ref := range profile.Plugins.PreBind.Enabled {
+		p, ok := plugins[ref.Name].(PreBindPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement PreBindPlugin", ref.Name)
+		}
+		f.preBind = append(f.preBind, p)
+	}
+	for _, ref := range profile.Plugins.Bind.Enabled {
+		p, ok := plugins[ref.Name].(BindPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q does not implement BindPlugin", ref.Name)
+		}
+		f.bind = append(f.bind, p)
+	}
+
+	return f, nil
+}
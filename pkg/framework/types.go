@@ -0,0 +1,179 @@
+// Package framework defines the pluggable scheduling framework used by the
+// batch scheduler, mirroring the extension-point model of upstream
+// kube-scheduler (PreFilter/Filter/Score/Reserve/Permit/PreBind/Bind).
+package framework
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Code is the outcome of running a plugin.
+type Code int
+
+const (
+	// Success means the plugin ran without objection.
+	Success Code = iota
+	// Unschedulable means the plugin rejected the pod/node pair; the pod
+	// should be retried on a later scheduling cycle.
+	Unschedulable
+	// Wait means a Permit plugin wants the cycle to pause (e.g. for gang
+	// members to reserve nodes) before binding proceeds.
+	Wait
+	// Error means the plugin failed unexpectedly.
+	Error
+)
+
+// Status is the result of running a single plugin.
+type Status struct {
+	code    Code
+	reasons []string
+	err     error
+	plugin  string
+}
+
+// NewStatus builds a Status with the given code and reasons.
+func NewStatus(code Code, reasons ...string) *Status {
+	return &Status{code: code, reasons: reasons}
+}
+
+// AsStatus wraps an error as an Error status.
+func AsStatus(err error) *Status {
+	if err == nil {
+		return nil
+	}
+	return &Status{code: Error, err: err}
+}
+
+// IsSuccess reports whether the status is nil or Success.
+func (s *Status) IsSuccess() bool {
+	return s == nil || s.code == Success
+}
+
+// Code returns the status code.
+func (s *Status) Code() Code {
+	if s == nil {
+		return Success
+	}
+	return s.code
+}
+
+// Message renders a human-readable summary, including which plugin produced it.
+func (s *Status) Message() string {
+	if s == nil {
+		return ""
+	}
+	if s.err != nil {
+		return fmt.Sprintf("%s: %v", s.plugin, s.err)
+	}
+	return fmt.Sprintf("%s: %v", s.plugin, s.reasons)
+}
+
+// WithPlugin stamps the status with the name of the plugin that produced it.
+func (s *Status) WithPlugin(name string) *Status {
+	if s == nil {
+		return nil
+	}
+	s.plugin = name
+	return s
+}
+
+// StateKey identifies a value stored in a CycleState.
+type StateKey string
+
+// StateData is data plugins can stash in a CycleState for later extension
+// points in the same scheduling cycle to read back.
+type StateData interface{}
+
+// CycleState carries data across extension points for a single scheduling
+// attempt. It is safe for concurrent use by multiple plugins/goroutines.
+type CycleState struct {
+	mu      sync.RWMutex
+	storage map[StateKey]StateData
+}
+
+// NewCycleState returns an empty CycleState.
+func NewCycleState() *CycleState {
+	return &CycleState{storage: make(map[StateKey]StateData)}
+}
+
+// Write stores a value under key.
+func (c *CycleState) Write(key StateKey, val StateData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.storage[key] = val
+}
+
+// Read retrieves the value stored under key, if any.
+func (c *CycleState) Read(key StateKey) (StateData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	val, ok := c.storage[key]
+	return val, ok
+}
+
+// Resource tracks CPU (milliCPU) and memory (bytes) quantities.
+type Resource struct {
+	MilliCPU int64
+	Memory   int64
+}
+
+// Sub returns r minus other.
+func (r Resource) Sub(other Resource) Resource {
+	return Resource{MilliCPU: r.MilliCPU - other.MilliCPU, Memory: r.Memory - other.Memory}
+}
+
+// Fits reports whether demand fits within r.
+func (r Resource) Fits(demand Resource) bool {
+	return r.MilliCPU >= demand.MilliCPU && r.Memory >= demand.Memory
+}
+
+// PodRequest sums the resource requests of a pod's containers.
+func PodRequest(pod *v1.Pod) Resource {
+	var res Resource
+	for _, c := range pod.Spec.Containers {
+		res.MilliCPU += c.Resources.Requests.Cpu().MilliValue()
+		res.Memory += c.Resources.Requests.Memory().Value()
+	}
+	return res
+}
+
+// NodeInfo is a node together with the information the framework needs to
+// filter and score it: its allocatable resources, current requested
+// resources from pods already placed on it, and the pods themselves (used by
+// topology-spread and affinity plugins).
+type NodeInfo struct {
+	Node      *v1.Node
+	Pods      []*v1.Pod
+	Allocatable Resource
+	Requested Resource
+}
+
+// Available returns the resources left on the node after subtracting Requested.
+func (n *NodeInfo) Available() Resource {
+	return n.Allocatable.Sub(n.Requested)
+}
+
+// AddPod records a pod as if it had already been placed on the node, updating
+// Requested and Pods. The scheduler uses it to consume a node's capacity
+// optimistically within a scheduling cycle once a pod has been placed there.
+func (n *NodeInfo) AddPod(pod *v1.Pod) {
+	n.Pods = append(n.Pods, pod)
+	req := PodRequest(pod)
+	n.Requested.MilliCPU += req.MilliCPU
+	n.Requested.Memory += req.Memory
+}
+
+// Clone returns a deep-enough copy of n for optimistic, per-attempt mutation.
+func (n *NodeInfo) Clone() *NodeInfo {
+	pods := make([]*v1.Pod, len(n.Pods))
+	copy(pods, n.Pods)
+	return &NodeInfo{Node: n.Node, Pods: pods, Allocatable: n.Allocatable, Requested: n.Requested}
+}
+
+// DefaultWaitTimeout is how long the framework pauses a pod's binding when a
+// Permit plugin returns Wait without a positive duration of its own.
+const DefaultWaitTimeout = 60 * time.Second
@@ -0,0 +1,36 @@
+// Package logging wires the scheduler's --v, --vmodule and --logging-format
+// flags through k8s.io/component-base/logs, the same mechanism upstream
+// kube-scheduler uses, so this binary's logging flags behave identically.
+package logging
+
+import (
+	"flag"
+	"fmt"
+
+	logsapi "k8s.io/component-base/logs/api/v1"
+	_ "k8s.io/component-base/logs/json" // register --logging-format=json
+)
+
+// Options holds the logging configuration and the flags that populate it.
+type Options struct {
+	config *logsapi.LoggingConfiguration
+}
+
+// NewOptions returns Options defaulted to klog's traditional text format.
+func NewOptions() *Options {
+	return &Options{config: logsapi.NewLoggingConfiguration()}
+}
+
+// AddFlags registers --v, --vmodule and --logging-format on fs.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	logsapi.AddGoFlags(o.config, fs)
+}
+
+// Apply validates the configured flags and installs the resulting logger as
+// klog's global logger. Call it once, after flag.Parse.
+func (o *Options) Apply() error {
+	if err := logsapi.ValidateAndApply(o.config, nil); err != nil {
+		return fmt.Errorf("apply logging configuration: %w", err)
+	}
+	return nil
+}
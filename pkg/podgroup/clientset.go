@@ -0,0 +1,127 @@
+// Package podgroup provides a typed client, informer, lister and
+// reconciling controller for the PodGroup custom resource.
+package podgroup
+
+import (
+	"context"
+
+	schedulingv1alpha1 "github.com/example/k8s-scheduler-new/pkg/apis/scheduling/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+)
+
+// scheme is the codec scheme used by the PodGroup REST client.
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntimeMust(schedulingv1alpha1.AddToScheme(scheme))
+}
+
+func utilruntimeMust(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Clientset is a typed client for the scheduling.example.io API group.
+type Clientset struct {
+	restClient rest.Interface
+}
+
+// NewForConfig builds a Clientset from a rest.Config.
+func NewForConfig(cfg *rest.Config) (*Clientset, error) {
+	config := *cfg
+	config.GroupVersion = &schedulingv1alpha1.SchemeGroupVersion
+	config.APIPath = "/apis"
+	codecs := serializer.NewCodecFactory(scheme)
+	config.NegotiatedSerializer = codecs.WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	restClient, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &Clientset{restClient: restClient}, nil
+}
+
+// PodGroups returns an interface for operating on PodGroups in a namespace.
+func (c *Clientset) PodGroups(namespace string) PodGroupInterface {
+	return &podGroupClient{restClient: c.restClient, ns: namespace}
+}
+
+// PodGroupInterface has methods to work with PodGroup resources.
+type PodGroupInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*schedulingv1alpha1.PodGroup, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*schedulingv1alpha1.PodGroupList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Create(ctx context.Context, podGroup *schedulingv1alpha1.PodGroup, opts metav1.CreateOptions) (*schedulingv1alpha1.PodGroup, error)
+	UpdateStatus(ctx context.Context, podGroup *schedulingv1alpha1.PodGroup, opts metav1.UpdateOptions) (*schedulingv1alpha1.PodGroup, error)
+}
+
+type podGroupClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+func (c *podGroupClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*schedulingv1alpha1.PodGroup, error) {
+	result := &schedulingv1alpha1.PodGroup{}
+	err := c.restClient.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(name).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroupClient) List(ctx context.Context, opts metav1.ListOptions) (*schedulingv1alpha1.PodGroupList, error) {
+	result := &schedulingv1alpha1.PodGroupList{}
+	err := c.restClient.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroupClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.restClient.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *podGroupClient) Create(ctx context.Context, podGroup *schedulingv1alpha1.PodGroup, opts metav1.CreateOptions) (*schedulingv1alpha1.PodGroup, error) {
+	result := &schedulingv1alpha1.PodGroup{}
+	err := c.restClient.Post().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroupClient) UpdateStatus(ctx context.Context, podGroup *schedulingv1alpha1.PodGroup, opts metav1.UpdateOptions) (*schedulingv1alpha1.PodGroup, error) {
+	result := &schedulingv1alpha1.PodGroup{}
+	err := c.restClient.Put().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(podGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
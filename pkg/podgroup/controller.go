@@ -0,0 +1,189 @@
+package podgroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	schedulingv1alpha1 "github.com/example/k8s-scheduler-new/pkg/apis/scheduling/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller reconciles PodGroup status by watching the pods that belong to it.
+type Controller struct {
+	client      *Clientset
+	informer    cache.SharedIndexInformer
+	lister      *Lister
+	podInformer coreinformers.PodInformer
+	queue       workqueue.RateLimitingInterface
+	recorder    events.EventRecorder
+}
+
+// NewController builds a PodGroup controller. podInformer is expected to already
+// be registered with the shared informer factory used by the rest of the scheduler.
+// recorder is used to surface phase transitions on the PodGroup object itself.
+func NewController(client *Clientset, podGroupInformer cache.SharedIndexInformer, podInformer coreinformers.PodInformer, recorder events.EventRecorder) *Controller {
+	c := &Controller{
+		client:      client,
+		informer:    podGroupInformer,
+		lister:      NewLister(podGroupInformer),
+		podInformer: podInformer,
+		queue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "podgroup"),
+		recorder:    recorder,
+	}
+
+	podGroupInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, newObj interface{}) { c.enqueue(newObj) },
+	})
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueuePodGroupForPod,
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePodGroupForPod(newObj) },
+		DeleteFunc: c.enqueuePodGroupForPod,
+	})
+
+	return c
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.ErrorS(err, "failed to compute key for PodGroup")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) enqueuePodGroupForPod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+	name := pod.Labels[schedulingv1alpha1.PodGroupLabel]
+	if name == "" {
+		return
+	}
+	c.queue.Add(pod.Namespace + "/" + name)
+}
+
+// Run starts the controller's worker loop and blocks until ctx is cancelled.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer c.queue.ShutDown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced, c.podInformer.Informer().HasSynced) {
+		return fmt.Errorf("failed to sync podgroup informers")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() { c.runWorker(ctx) }, time.Second, ctx.Done())
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	obj, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	key := obj.(string)
+	if err := c.sync(ctx, key); err != nil {
+		klog.FromContext(ctx).Error(err, "failed to sync PodGroup", "key", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(obj)
+	}
+	return true
+}
+
+func (c *Controller) sync(ctx context.Context, key string) error {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	pg, err := c.lister.PodGroups(ns).Get(name)
+	if err != nil {
+		return err
+	}
+
+	pods, err := c.podInformer.Lister().Pods(ns).List(labels.SelectorFromSet(labels.Set{
+		schedulingv1alpha1.PodGroupLabel: name,
+	}))
+	if err != nil {
+		return err
+	}
+
+	status := computeStatus(pg, pods)
+	if statusEqual(pg.Status, status) {
+		return nil
+	}
+
+	if status.Phase != pg.Status.Phase {
+		c.recorder.Eventf(pg, nil, v1.EventTypeNormal, string(status.Phase), "StatusUpdate", "PodGroup %s transitioned to phase %s", pg.Name, status.Phase)
+	}
+
+	updated := pg.DeepCopy()
+	updated.Status = status
+	_, err = c.client.PodGroups(ns).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+func computeStatus(pg *schedulingv1alpha1.PodGroup, pods []*v1.Pod) schedulingv1alpha1.PodGroupStatus {
+	status := pg.Status
+	status.Running, status.Succeeded, status.Failed = 0, 0, 0
+
+	scheduled := 0
+	for _, p := range pods {
+		switch p.Status.Phase {
+		case v1.PodRunning:
+			status.Running++
+		case v1.PodSucceeded:
+			status.Succeeded++
+		case v1.PodFailed:
+			status.Failed++
+		}
+		if p.Spec.NodeName != "" {
+			scheduled++
+		}
+	}
+
+	minMember := int(pg.Spec.MinMember)
+	switch {
+	case int(status.Failed) > 0 && scheduled < minMember:
+		status.Phase = schedulingv1alpha1.PodGroupFailed
+	case int(status.Running+status.Succeeded) >= minMember && minMember > 0:
+		status.Phase = schedulingv1alpha1.PodGroupRunning
+	case scheduled >= minMember && minMember > 0:
+		status.Phase = schedulingv1alpha1.PodGroupScheduled
+		if status.ScheduleStartTime == nil {
+			now := metav1.Now()
+			status.ScheduleStartTime = &now
+		}
+	case len(pods) > 0:
+		status.Phase = schedulingv1alpha1.PodGroupPreScheduling
+	default:
+		status.Phase = schedulingv1alpha1.PodGroupPending
+	}
+	return status
+}
+
+func statusEqual(a, b schedulingv1alpha1.PodGroupStatus) bool {
+	return a.Phase == b.Phase && a.Running == b.Running && a.Succeeded == b.Succeeded && a.Failed == b.Failed
+}
@@ -0,0 +1,97 @@
+package podgroup
+
+import (
+	"testing"
+
+	schedulingv1alpha1 "github.com/example/k8s-scheduler-new/pkg/apis/scheduling/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func podWith(phase v1.PodPhase, nodeName string) *v1.Pod {
+	return &v1.Pod{
+		Status: v1.PodStatus{Phase: phase},
+		Spec:   v1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func TestComputeStatusPhaseTransitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		minMember int32
+		pods      []*v1.Pod
+		wantPhase schedulingv1alpha1.PodGroupPhase
+	}{
+		{
+			name:      "no pods yet",
+			minMember: 2,
+			pods:      nil,
+			wantPhase: schedulingv1alpha1.PodGroupPending,
+		},
+		{
+			name:      "some pods but not enough scheduled",
+			minMember: 2,
+			pods:      []*v1.Pod{podWith(v1.PodPending, "")},
+			wantPhase: schedulingv1alpha1.PodGroupPreScheduling,
+		},
+		{
+			name:      "MinMember scheduled but not yet running",
+			minMember: 2,
+			pods:      []*v1.Pod{podWith(v1.PodPending, "node-a"), podWith(v1.PodPending, "node-b")},
+			wantPhase: schedulingv1alpha1.PodGroupScheduled,
+		},
+		{
+			name:      "MinMember running",
+			minMember: 2,
+			pods:      []*v1.Pod{podWith(v1.PodRunning, "node-a"), podWith(v1.PodRunning, "node-b")},
+			wantPhase: schedulingv1alpha1.PodGroupRunning,
+		},
+		{
+			name:      "a failure below MinMember scheduled fails the group",
+			minMember: 2,
+			pods:      []*v1.Pod{podWith(v1.PodFailed, "node-a")},
+			wantPhase: schedulingv1alpha1.PodGroupFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := &schedulingv1alpha1.PodGroup{
+				Spec: schedulingv1alpha1.PodGroupSpec{MinMember: tt.minMember},
+			}
+			status := computeStatus(pg, tt.pods)
+			if status.Phase != tt.wantPhase {
+				t.Errorf("computeStatus() phase = %s, want %s", status.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestComputeStatusSetsScheduleStartTimeOnce(t *testing.T) {
+	pg := &schedulingv1alpha1.PodGroup{Spec: schedulingv1alpha1.PodGroupSpec{MinMember: 1}}
+	pods := []*v1.Pod{podWith(v1.PodPending, "node-a")}
+
+	first := computeStatus(pg, pods)
+	if first.ScheduleStartTime == nil {
+		t.Fatal("expected ScheduleStartTime to be set once the group is Scheduled")
+	}
+
+	pg.Status = first
+	second := computeStatus(pg, pods)
+	if !second.ScheduleStartTime.Equal(first.ScheduleStartTime) {
+		t.Errorf("ScheduleStartTime changed across reconciles: first=%v second=%v", first.ScheduleStartTime, second.ScheduleStartTime)
+	}
+}
+
+func TestStatusEqual(t *testing.T) {
+	base := schedulingv1alpha1.PodGroupStatus{Phase: schedulingv1alpha1.PodGroupRunning, Running: 2}
+	same := base
+	different := base
+	different.Running = 3
+
+	if !statusEqual(base, same) {
+		t.Error("statusEqual() = false, want true for identical status")
+	}
+	if statusEqual(base, different) {
+		t.Error("statusEqual() = true, want false for status differing in Running count")
+	}
+}
@@ -0,0 +1,72 @@
+package podgroup
+
+import (
+	"context"
+	"time"
+
+	schedulingv1alpha1 "github.com/example/k8s-scheduler-new/pkg/apis/scheduling/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewSharedInformer returns an informer that keeps an in-memory cache of
+// PodGroups in sync with the API server, scoped to a single namespace (or
+// metav1.NamespaceAll for cluster-wide).
+func NewSharedInformer(client *Clientset, namespace string, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return client.PodGroups(namespace).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return client.PodGroups(namespace).Watch(context.Background(), opts)
+		},
+	}
+	return cache.NewSharedIndexInformer(lw, &schedulingv1alpha1.PodGroup{}, resyncPeriod, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+	})
+}
+
+// Lister exposes read-only, cache-backed access to PodGroups.
+type Lister struct {
+	indexer cache.Indexer
+}
+
+// NewLister builds a Lister backed by the given informer's indexer.
+func NewLister(informer cache.SharedIndexInformer) *Lister {
+	return &Lister{indexer: informer.GetIndexer()}
+}
+
+// PodGroups returns a namespace-scoped lister.
+func (l *Lister) PodGroups(namespace string) NamespaceLister {
+	return NamespaceLister{indexer: l.indexer, ns: namespace}
+}
+
+// NamespaceLister lists and gets PodGroups within a single namespace from the local cache.
+type NamespaceLister struct {
+	indexer cache.Indexer
+	ns      string
+}
+
+// Get retrieves the PodGroup with the given name from the local cache.
+func (n NamespaceLister) Get(name string) (*schedulingv1alpha1.PodGroup, error) {
+	obj, exists, err := n.indexer.GetByKey(n.ns + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(schedulingv1alpha1.Resource("podgroups"), name)
+	}
+	return obj.(*schedulingv1alpha1.PodGroup), nil
+}
+
+// List returns all PodGroups in the namespace present in the local cache.
+func (n NamespaceLister) List() ([]*schedulingv1alpha1.PodGroup, error) {
+	var result []*schedulingv1alpha1.PodGroup
+	err := cache.ListAllByNamespace(n.indexer, n.ns, nil, func(obj interface{}) {
+		result = append(result, obj.(*schedulingv1alpha1.PodGroup))
+	})
+	return result, err
+}
@@ -0,0 +1,191 @@
+// Package preemption selects and evicts victim pods so a higher-priority
+// pod (or gang) that has no feasible node can still be scheduled, mirroring
+// upstream kube-scheduler's PostFilter preemption plugin.
+package preemption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PDBLister gives the preemptor read access to PodDisruptionBudgets so it
+// does not evict a pod the budget currently protects.
+type PDBLister interface {
+	List() ([]*policyv1.PodDisruptionBudget, error)
+}
+
+// Preemptor finds and evicts victim pods on behalf of a pod that could not
+// be scheduled.
+type Preemptor struct {
+	client    kubernetes.Interface
+	pdbLister PDBLister
+}
+
+// NewPreemptor constructs a Preemptor.
+func NewPreemptor(client kubernetes.Interface, pdbLister PDBLister) *Preemptor {
+	return &Preemptor{client: client, pdbLister: pdbLister}
+}
+
+// Candidate describes the victims that must be removed from a node before
+// pod would fit there.
+type Candidate struct {
+	NodeName          string
+	Victims           []*v1.Pod
+	HighestVictimPrio int32
+	GracefulCost      int64
+}
+
+// FindVictims simulates evicting pods from nodeInfo, in ascending priority
+// order, until pod fits. It returns nil if the node cannot be made to fit
+// pod even after evicting every evictable pod (one protected by a PDB that
+// has no disruptions left, or already at/above pod's own priority, is never
+// evicted).
+func (p *Preemptor) FindVictims(pod *v1.Pod, nodeInfo *framework.NodeInfo) (*Candidate, error) {
+	prio := podPriority(pod)
+
+	evictable := make([]*v1.Pod, 0, len(nodeInfo.Pods))
+	for _, victim := range nodeInfo.Pods {
+		if podPriority(victim) >= prio {
+			continue
+		}
+		evictable = append(evictable, victim)
+	}
+	sort.Slice(evictable, func(i, j int) bool {
+		return podPriority(evictable[i]) < podPriority(evictable[j])
+	})
+
+	protected, err := p.protectedByPDB(evictable)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := nodeInfo.Available()
+	demand := framework.PodRequest(pod)
+	var victims []*v1.Pod
+	var highest int32
+	var gracefulCost int64
+	for _, victim := range evictable {
+		if remaining.Fits(demand) {
+			break
+		}
+		if protected[victim.UID] {
+			continue
+		}
+		victims = append(victims, victim)
+		req := framework.PodRequest(victim)
+		remaining.MilliCPU += req.MilliCPU
+		remaining.Memory += req.Memory
+		if prio := podPriority(victim); prio > highest {
+			highest = prio
+		}
+		gracefulCost += gracePeriod(victim)
+	}
+	if !remaining.Fits(demand) {
+		return nil, nil
+	}
+	return &Candidate{
+		NodeName:          nodeInfo.Node.Name,
+		Victims:           victims,
+		HighestVictimPrio: highest,
+		GracefulCost:      gracefulCost,
+	}, nil
+}
+
+func (p *Preemptor) protectedByPDB(pods []*v1.Pod) (map[types.UID]bool, error) {
+	protected := make(map[types.UID]bool)
+	if len(pods) == 0 {
+		return protected, nil
+	}
+	pdbs, err := p.pdbLister.List()
+	if err != nil {
+		return nil, fmt.Errorf("list PodDisruptionBudgets: %w", err)
+	}
+	for _, pod := range pods {
+		for _, pdb := range pdbs {
+			if pdb.Namespace != pod.Namespace {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			if pdb.Status.DisruptionsAllowed <= 0 {
+				protected[pod.UID] = true
+			}
+		}
+	}
+	return protected, nil
+}
+
+// BestCandidate picks, among feasible candidates, the one minimizing
+// (highest-priority victim, victim count, total graceful-termination cost),
+// breaking ties by node name so results stay deterministic.
+func BestCandidate(candidates []*Candidate) *Candidate {
+	var best *Candidate
+	for _, c := range candidates {
+		if c == nil {
+			continue
+		}
+		if best == nil || less(c, best) {
+			best = c
+		}
+	}
+	return best
+}
+
+func less(a, b *Candidate) bool {
+	if a.HighestVictimPrio != b.HighestVictimPrio {
+		return a.HighestVictimPrio < b.HighestVictimPrio
+	}
+	if len(a.Victims) != len(b.Victims) {
+		return len(a.Victims) < len(b.Victims)
+	}
+	if a.GracefulCost != b.GracefulCost {
+		return a.GracefulCost < b.GracefulCost
+	}
+	return a.NodeName < b.NodeName
+}
+
+// Evict issues an eviction API call for every victim in candidate.
+func (p *Preemptor) Evict(ctx context.Context, candidate *Candidate) error {
+	for _, victim := range candidate.Victims {
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: victim.Name, Namespace: victim.Namespace},
+		}
+		if err := p.client.PolicyV1().Evictions(victim.Namespace).Evict(ctx, eviction); err != nil {
+			return fmt.Errorf("evict pod %s/%s: %w", victim.Namespace, victim.Name, err)
+		}
+	}
+	return nil
+}
+
+// NominateNode patches pod.Status.NominatedNodeName so later scheduling
+// cycles (and `kubectl describe pod`) know which node it is preempting for.
+func (p *Preemptor) NominateNode(ctx context.Context, pod *v1.Pod, nodeName string) error {
+	patch := []byte(fmt.Sprintf(`{"status":{"nominatedNodeName":%q}}`, nodeName))
+	_, err := p.client.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
+
+func podPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+func gracePeriod(pod *v1.Pod) int64 {
+	if pod.Spec.TerminationGracePeriodSeconds == nil {
+		return 0
+	}
+	return *pod.Spec.TerminationGracePeriodSeconds
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import "time"
+
+// LeaderElectionOptions configures active/passive HA via
+// k8s.io/client-go/tools/leaderelection, backed by a Lease in
+// coordination.k8s.io.
+type LeaderElectionOptions struct {
+	Enabled bool
+	// LeaseNamespace and LeaseName identify the Lease used as the lock.
+	LeaseNamespace string
+	LeaseName      string
+	// LeaseDuration, RenewDeadline and RetryPeriod mirror
+	// leaderelection.LeaderElectionConfig's fields of the same name.
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// withDefaults fills in the Lease namespace and name (kube-system and
+// batch-scheduler) and 15s/10s/2s lease, renew and retry durations,
+// leaving any explicitly-set value untouched.
+func (o LeaderElectionOptions) withDefaults() LeaderElectionOptions {
+	if o.LeaseNamespace == "" {
+		o.LeaseNamespace = "kube-system"
+	}
+	if o.LeaseName == "" {
+		o.LeaseName = "batch-scheduler"
+	}
+	if o.LeaseDuration == 0 {
+		o.LeaseDuration = 15 * time.Second
+	}
+	if o.RenewDeadline == 0 {
+		o.RenewDeadline = 10 * time.Second
+	}
+	if o.RetryPeriod == 0 {
+		o.RetryPeriod = 2 * time.Second
+	}
+	return o
+}
@@ -0,0 +1,120 @@
+//go:build integration
+
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestLeaderElectionOnlyOneSchedulerBinds starts two BatchSchedulers against
+// the same envtest apiserver, contending for the same leader-election
+// Lease, and verifies that only one ever holds leadership (and therefore
+// only one ever binds the test pod) at a time. Requires a real apiserver
+// and etcd binary; set KUBEBUILDER_ASSETS (see sigs.k8s.io/controller-runtime
+// setup-envtest) before running `go test -tags=integration ./pkg/scheduler/...`.
+func TestLeaderElectionOnlyOneSchedulerBinds(t *testing.T) {
+	env := &envtest.Environment{
+		CRDDirectoryPaths: []string{filepath.Join("testdata", "crds")},
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("start envtest environment (is KUBEBUILDER_ASSETS set?): %v", err)
+	}
+	defer func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("stop envtest environment: %v", err)
+		}
+	}()
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("build clientset: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	node, err = client.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create node: %v", err)
+	}
+	node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	if _, err := client.CoreV1().Nodes().UpdateStatus(ctx, node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("mark node ready: %v", err)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+		Spec: v1.PodSpec{
+			SchedulerName: "batch-scheduler",
+			Containers:    []v1.Container{{Name: "c", Image: "busybox"}},
+		},
+	}
+	if _, err := client.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+
+	leaseOpts := LeaderElectionOptions{
+		Enabled:        true,
+		LeaseNamespace: "default",
+		LeaseName:      "test-batch-scheduler",
+		LeaseDuration:  2 * time.Second,
+		RenewDeadline:  1 * time.Second,
+		RetryPeriod:    250 * time.Millisecond,
+	}
+	s1, err := New(ctx, cfg, Options{SchedulerName: "batch-scheduler", LeaderElection: leaseOpts, MetricsRegistry: prometheus.NewRegistry()})
+	if err != nil {
+		t.Fatalf("construct first scheduler: %v", err)
+	}
+	s2, err := New(ctx, cfg, Options{SchedulerName: "batch-scheduler", LeaderElection: leaseOpts, MetricsRegistry: prometheus.NewRegistry()})
+	if err != nil {
+		t.Fatalf("construct second scheduler: %v", err)
+	}
+
+	for _, s := range []*BatchScheduler{s1, s2} {
+		s := s
+		go func() {
+			if err := s.Run(ctx); err != nil && ctx.Err() == nil {
+				t.Logf("scheduler %s exited: %v", s.identity, err)
+			}
+		}()
+	}
+
+	deadline := time.Now().Add(20 * time.Second)
+	for {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for pod to be bound")
+		}
+
+		leading := 0
+		if testutil.ToFloat64(s1.metrics.IsLeader.WithLabelValues(s1.identity)) == 1 {
+			leading++
+		}
+		if testutil.ToFloat64(s2.metrics.IsLeader.WithLabelValues(s2.identity)) == 1 {
+			leading++
+		}
+		if leading > 1 {
+			t.Fatalf("expected at most one scheduler to hold leadership, got %d", leading)
+		}
+
+		got, err := client.CoreV1().Pods("default").Get(ctx, "test-pod", metav1.GetOptions{})
+		if err == nil && got.Spec.NodeName != "" {
+			if leading != 1 {
+				t.Fatalf("pod was bound but exactly one scheduler wasn't recorded as leading (got %d)", leading)
+			}
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
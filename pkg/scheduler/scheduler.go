@@ -4,38 +4,100 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"sync"
 	"time"
 
+	schedulingv1alpha1 "github.com/example/k8s-scheduler-new/pkg/apis/scheduling/v1alpha1"
+	schedcache "github.com/example/k8s-scheduler-new/pkg/cache"
+	"github.com/example/k8s-scheduler-new/pkg/framework"
+	"github.com/example/k8s-scheduler-new/pkg/framework/plugins"
+	"github.com/example/k8s-scheduler-new/pkg/metrics"
+	"github.com/example/k8s-scheduler-new/pkg/podgroup"
+	"github.com/example/k8s-scheduler-new/pkg/preemption"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	policyinformers "k8s.io/client-go/informers/policy/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
+// queueName labels every queue-depth metric. The scheduler only runs one
+// work queue today; the label exists so a future per-priority-class queue
+// split doesn't change the metric's shape.
+const queueName = "default"
+
 // Options configures scheduler behavior.
 type Options struct {
-	SchedulerName          string
-	GangLabel              string
-	MinAvailableAnnotation string
+	SchedulerName string
+	GangLabel     string
+	// ProfilePath is an optional path to a KubeSchedulerConfiguration-style
+	// YAML file. When empty, framework.DefaultProfile is used.
+	ProfilePath string
+	// LeaderElection configures active/passive HA across replicas. Disabled
+	// by default, matching a single-replica deployment.
+	LeaderElection LeaderElectionOptions
+	// HealthBindAddress is the address the /healthz and /readyz endpoints
+	// are served on, e.g. ":10259". Empty disables the HTTP server.
+	HealthBindAddress string
+	// MetricsBindAddress is the address the /metrics endpoint is served on,
+	// e.g. ":10251". Empty disables the metrics server.
+	MetricsBindAddress string
+	// MetricsRegistry is the Prometheus registerer collectors are registered
+	// against. Defaults to prometheus.DefaultRegisterer; tests that run more
+	// than one BatchScheduler in the same process must supply a distinct
+	// registry per instance to avoid duplicate-registration panics.
+	MetricsRegistry prometheus.Registerer
 }
 
-// BatchScheduler implements a minimal gang-style scheduler.
+// BatchScheduler implements a gang-aware scheduler driven by a pluggable
+// framework.Framework, mirroring kube-scheduler's extension-point model.
 type BatchScheduler struct {
-	client                 kubernetes.Interface
-	podInformer            coreinformers.PodInformer
-	nodeInformer           coreinformers.NodeInformer
-	queue                  workqueue.RateLimitingInterface
-	schedulerName          string
-	gangLabel              string
-	minAvailableAnnotation string
+	client             kubernetes.Interface
+	podGroupClient     *podgroup.Clientset
+	podInformer        coreinformers.PodInformer
+	nodeInformer       coreinformers.NodeInformer
+	podGroupInformer   cache.SharedIndexInformer
+	podGroupLister     *podgroup.Lister
+	podGroupController *podgroup.Controller
+	pdbInformer        policyinformers.PodDisruptionBudgetInformer
+	queue              workqueue.RateLimitingInterface
+	schedulerName      string
+	gangLabel          string
+	framework          *framework.Framework
+	preemptor          *preemption.Preemptor
+	cache              *schedcache.Cache
+	leaderElection     LeaderElectionOptions
+	healthBindAddress  string
+	metricsBindAddress string
+	identity           string
+	currentLeaderMu    sync.RWMutex
+	currentLeader      string
+	metrics            *metrics.Metrics
+	eventBroadcaster   events.EventBroadcasterAdapter
+	recorder           events.EventRecorder
+	// logger is the base logger handed to New, for callbacks (informer event
+	// handlers) that have no per-call context to pull one from via
+	// klog.FromContext. Scheduling-cycle code instead derives its own child
+	// logger from the ctx it is given; see schedulePod.
+	logger logr.Logger
 }
 
 // New constructs the scheduler and sets up informers.
@@ -47,50 +109,295 @@ func New(ctx context.Context, cfg *rest.Config, opts Options) (*BatchScheduler,
 	if err != nil {
 		return nil, fmt.Errorf("create clientset: %w", err)
 	}
+	podGroupClient, err := podgroup.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create podgroup clientset: %w", err)
+	}
 
 	factory := informers.NewSharedInformerFactory(client, 30*time.Second)
 	podInformer := factory.Core().V1().Pods()
 	nodeInformer := factory.Core().V1().Nodes()
+	pdbInformer := factory.Policy().V1().PodDisruptionBudgets()
+	podGroupInformer := podgroup.NewSharedInformer(podGroupClient, metav1.NamespaceAll, 30*time.Second)
+
+	metricsRegistry := opts.MetricsRegistry
+	if metricsRegistry == nil {
+		metricsRegistry = prometheus.DefaultRegisterer
+	}
 
 	s := &BatchScheduler{
-		client:                 client,
-		podInformer:            podInformer,
-		nodeInformer:           nodeInformer,
-		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "batch-scheduler"),
-		schedulerName:          opts.SchedulerName,
-		gangLabel:              opts.GangLabel,
-		minAvailableAnnotation: opts.MinAvailableAnnotation,
+		client:             client,
+		podGroupClient:     podGroupClient,
+		podInformer:        podInformer,
+		nodeInformer:       nodeInformer,
+		podGroupInformer:   podGroupInformer,
+		podGroupLister:     podgroup.NewLister(podGroupInformer),
+		pdbInformer:        pdbInformer,
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "batch-scheduler"),
+		schedulerName:      opts.SchedulerName,
+		gangLabel:          opts.GangLabel,
+		leaderElection:     opts.LeaderElection.withDefaults(),
+		healthBindAddress:  opts.HealthBindAddress,
+		metricsBindAddress: opts.MetricsBindAddress,
+		metrics:            metrics.New(metricsRegistry),
+		logger:             klog.FromContext(ctx),
+	}
+	s.preemptor = preemption.NewPreemptor(client, (*pdbLister)(s))
+	s.cache = schedcache.New()
+	s.eventBroadcaster = events.NewEventBroadcasterAdapter(client)
+	s.recorder = s.eventBroadcaster.NewRecorder(opts.SchedulerName)
+	s.podGroupController = podgroup.NewController(podGroupClient, podGroupInformer, podInformer, s.recorder)
+
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("determine leader election identity: %w", err)
 	}
+	s.identity = identity + "_" + string(uuid.NewUUID())
+
+	profile := framework.DefaultProfile(opts.SchedulerName)
+	if opts.ProfilePath != "" {
+		override, err := framework.LoadProfile(opts.ProfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("load scheduler profile: %w", err)
+		}
+		profile = framework.MergeProfile(profile, override)
+	}
+	fw, err := framework.NewFramework(plugins.NewDefaultRegistry(), profile, client, (*nodeInfoLister)(s), (*gangResolver)(s))
+	if err != nil {
+		return nil, fmt.Errorf("build framework: %w", err)
+	}
+	s.framework = fw
 
 	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    s.enqueueIfUnscheduled,
 		UpdateFunc: func(_, newObj interface{}) { s.enqueueIfUnscheduled(newObj) },
 	})
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*v1.Pod); ok {
+				s.cache.AddPod(pod)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPod, oldOK := oldObj.(*v1.Pod)
+			newPod, newOK := newObj.(*v1.Pod)
+			if oldOK && newOK {
+				s.cache.UpdatePod(oldPod, newPod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if pod, ok := obj.(*v1.Pod); ok {
+				s.cache.RemovePod(pod)
+				return
+			}
+			if tomb, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				if pod, ok := tomb.Obj.(*v1.Pod); ok {
+					s.cache.RemovePod(pod)
+				}
+			}
+		},
+	})
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*v1.Node); ok {
+				s.cache.AddNode(node)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if node, ok := newObj.(*v1.Node); ok {
+				s.cache.AddNode(node)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if node, ok := obj.(*v1.Node); ok {
+				s.cache.RemoveNode(node)
+				return
+			}
+			if tomb, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				if node, ok := tomb.Obj.(*v1.Node); ok {
+					s.cache.RemoveNode(node)
+				}
+			}
+		},
+	})
 
 	return s, nil
 }
 
-// Run starts informers and worker loops.
+// Run starts informers, event recording, the health and metrics servers,
+// and (once leadership is held, if leader election is enabled) the
+// scheduling worker loops.
 func (s *BatchScheduler) Run(ctx context.Context) error {
-	klog.InfoS("starting batch scheduler", "scheduler", s.schedulerName)
+	logger := klog.FromContext(ctx)
+	logger.Info("starting batch scheduler", "scheduler", s.schedulerName, "identity", s.identity)
 	defer s.queue.ShutDown()
 
+	s.eventBroadcaster.StartRecordingToSink(ctx.Done())
+
 	go s.podInformer.Informer().Run(ctx.Done())
 	go s.nodeInformer.Informer().Run(ctx.Done())
+	go s.pdbInformer.Informer().Run(ctx.Done())
+	go s.podGroupInformer.Run(ctx.Done())
 
-	if ok := cache.WaitForCacheSync(ctx.Done(), s.podInformer.Informer().HasSynced, s.nodeInformer.Informer().HasSynced); !ok {
+	if ok := cache.WaitForCacheSync(ctx.Done(), s.podInformer.Informer().HasSynced, s.nodeInformer.Informer().HasSynced, s.pdbInformer.Informer().HasSynced, s.podGroupInformer.HasSynced); !ok {
 		return fmt.Errorf("failed to sync informers")
 	}
 
+	if s.healthBindAddress != "" {
+		go s.runHealthServer(ctx)
+	}
+	if s.metricsBindAddress != "" {
+		go s.runMetricsServer(ctx)
+	}
+
+	if !s.leaderElection.Enabled {
+		s.setLeader(s.identity)
+		s.runAsLeader(ctx)
+		return nil
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      s.leaderElection.LeaseName,
+			Namespace: s.leaderElection.LeaseNamespace,
+		},
+		Client: s.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: s.identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   s.leaderElection.LeaseDuration,
+		RenewDeadline:   s.leaderElection.RenewDeadline,
+		RetryPeriod:     s.leaderElection.RetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadingCtx context.Context) {
+				logger.Info("acquired leadership", "identity", s.identity)
+				s.setLeader(s.identity)
+				s.runAsLeader(leadingCtx)
+			},
+			OnStoppedLeading: func() {
+				logger.Info("stopped leading, worker loops released", "identity", s.identity)
+				s.setLeader("")
+			},
+			OnNewLeader: func(identity string) {
+				if identity != s.identity {
+					logger.Info("observed new leader", "identity", identity)
+				}
+				s.setCurrentLeader(identity)
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create leader elector: %w", err)
+	}
+
+	elector.Run(ctx)
+	return nil
+}
+
+// runAsLeader starts the PodGroup controller and the scheduling worker loop
+// and blocks until ctx is done, i.e. until leadership is lost or the process
+// is shutting down.
+func (s *BatchScheduler) runAsLeader(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	go func() {
+		if err := s.podGroupController.Run(ctx, 1); err != nil {
+			logger.Error(err, "podgroup controller exited")
+		}
+	}()
+
 	worker := func() {
 		for s.processNextItem(ctx) {
 		}
 	}
-
 	go wait.Until(worker, time.Second, ctx.Done())
 
 	<-ctx.Done()
-	return nil
+}
+
+// setLeader records whether identity (normally s.identity or "") is the
+// current scheduling leader via the scheduler_is_leader gauge, labeled with
+// this replica's own identity, and updates the identity /healthz reports.
+func (s *BatchScheduler) setLeader(identity string) {
+	leading := 0.0
+	if identity == s.identity {
+		leading = 1
+	}
+	s.metrics.IsLeader.WithLabelValues(s.identity).Set(leading)
+	s.setCurrentLeader(identity)
+}
+
+// setCurrentLeader records the identity of whichever replica this one last
+// observed holding leadership, for /healthz.
+func (s *BatchScheduler) setCurrentLeader(identity string) {
+	s.currentLeaderMu.Lock()
+	defer s.currentLeaderMu.Unlock()
+	s.currentLeader = identity
+}
+
+// currentLeaderIdentity returns the identity last recorded by setLeader or
+// setCurrentLeader, or "" if no leader has been observed yet.
+func (s *BatchScheduler) currentLeaderIdentity() string {
+	s.currentLeaderMu.RLock()
+	defer s.currentLeaderMu.RUnlock()
+	return s.currentLeader
+}
+
+// informersSynced reports whether every informer the scheduler depends on
+// has completed its initial list, for use by /readyz.
+func (s *BatchScheduler) informersSynced() bool {
+	return s.podInformer.Informer().HasSynced() &&
+		s.nodeInformer.Informer().HasSynced() &&
+		s.pdbInformer.Informer().HasSynced() &&
+		s.podGroupInformer.HasSynced()
+}
+
+// runHealthServer serves /healthz (process is up, annotated with the last
+// observed leader identity) and /readyz (every informer has synced) on
+// s.healthBindAddress until ctx is done.
+func (s *BatchScheduler) runHealthServer(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "ok\nleader=%s\n", s.currentLeaderIdentity())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !s.informersSynced() {
+			http.Error(w, "informers not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	server := &http.Server{Addr: s.healthBindAddress, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.FromContext(ctx).Error(err, "health server exited")
+	}
+}
+
+// runMetricsServer serves /metrics via promhttp on s.metricsBindAddress
+// until ctx is done.
+func (s *BatchScheduler) runMetricsServer(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{Addr: s.metricsBindAddress, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.FromContext(ctx).Error(err, "metrics server exited")
+	}
 }
 
 func (s *BatchScheduler) enqueueIfUnscheduled(obj interface{}) {
@@ -106,10 +413,12 @@ func (s *BatchScheduler) enqueueIfUnscheduled(obj interface{}) {
 	}
 	key, err := cache.MetaNamespaceKeyFunc(pod)
 	if err != nil {
-		klog.ErrorS(err, "failed to compute key")
+		s.logger.Error(err, "failed to compute key")
 		return
 	}
 	s.queue.Add(key)
+	s.metrics.QueueIncomingPodsTotal.WithLabelValues(queueName).Inc()
+	s.metrics.PendingPods.WithLabelValues(queueName).Set(float64(s.queue.Len()))
 }
 
 func (s *BatchScheduler) processNextItem(ctx context.Context) bool {
@@ -118,6 +427,7 @@ func (s *BatchScheduler) processNextItem(ctx context.Context) bool {
 		return false
 	}
 	defer s.queue.Done(obj)
+	defer func() { s.metrics.PendingPods.WithLabelValues(queueName).Set(float64(s.queue.Len())) }()
 
 	key, ok := obj.(string)
 	if !ok {
@@ -126,7 +436,7 @@ func (s *BatchScheduler) processNextItem(ctx context.Context) bool {
 	}
 
 	if err := s.schedulePod(ctx, key); err != nil {
-		klog.ErrorS(err, "failed to schedule", "key", key)
+		klog.FromContext(ctx).Error(err, "failed to schedule", "key", key)
 		s.queue.AddRateLimited(key)
 	} else {
 		s.queue.Forget(obj)
@@ -148,12 +458,7 @@ func (s *BatchScheduler) schedulePod(ctx context.Context, key string) error {
 		return nil
 	}
 
-	gangID := pod.Labels[s.gangLabel]
-	if gangID == "" {
-		gangID = pod.Name // treat single pod as its own gang
-	}
-
-	gangPods, err := s.podsForGang(ns, gangID)
+	gangID, minMember, gangPods, err := (*gangResolver)(s).ResolveGang(pod)
 	if err != nil {
 		return err
 	}
@@ -161,181 +466,397 @@ func (s *BatchScheduler) schedulePod(ctx context.Context, key string) error {
 		return nil
 	}
 
-	minAvailable := s.resolveMinAvailable(gangPods)
+	logger := klog.FromContext(ctx).WithValues("pod", klog.KObj(pod), "gang", gangID, "cycleID", string(uuid.NewUUID()))
+	ctx = klog.NewContext(ctx, logger)
+
+	attemptStart := time.Now()
+	result := "scheduled"
+	defer func() {
+		s.metrics.SchedulingAttemptDuration.WithLabelValues(result).Observe(time.Since(attemptStart).Seconds())
+	}()
+
 	ready := filterUnboundPods(gangPods)
-	if len(ready) < minAvailable {
-		return fmt.Errorf("gang %s not ready, need %d pods, have %d", gangID, minAvailable, len(ready))
+	if len(ready) < minMember {
+		result = "unschedulable"
+		return fmt.Errorf("gang %s not ready, need %d pods, have %d", gangID, minMember, len(ready))
 	}
+	s.metrics.GangWaitSeconds.Observe(time.Since(oldestCreationTimestamp(ready)).Seconds())
 
 	plan, err := s.planGang(ctx, ready)
 	if err != nil {
+		var infeasible *errNoFeasibleNode
+		if errors.As(err, &infeasible) {
+			logger.V(2).Info("no feasible node, attempting preemption", "infeasiblePod", infeasible.pod.Name)
+			result = "unschedulable"
+			return s.preemptForGang(ctx, ready)
+		}
+		result = "error"
+		s.recordFailedScheduling(pod, err)
 		return err
 	}
 
 	for i, p := range ready {
 		nodeName := plan[i]
 		if nodeName == "" {
-			return fmt.Errorf("missing node assignment for pod %s", p.Name)
+			result = "error"
+			err := fmt.Errorf("missing node assignment for pod %s", p.Name)
+			s.recordFailedScheduling(p, err)
+			return err
 		}
 		if err := s.bindPod(ctx, p, nodeName); err != nil {
-			return fmt.Errorf("bind pod %s: %w", p.Name, err)
+			result = "error"
+			wrapped := fmt.Errorf("bind pod %s: %w", p.Name, err)
+			s.recordFailedScheduling(p, wrapped)
+			return wrapped
 		}
+		s.recorder.Eventf(p, nil, v1.EventTypeNormal, "Scheduled", "Scheduling", "Successfully assigned %s to %s", p.Name, nodeName)
 	}
+	logger.Info("gang scheduled", "pods", len(ready))
 	return nil
 }
 
-func (s *BatchScheduler) resolveMinAvailable(gang []*v1.Pod) int {
-	if len(gang) == 0 {
-		return 0
-	}
-	if value, ok := gang[0].Annotations[s.minAvailableAnnotation]; ok {
-		if intVal, err := intstr.GetValueFromIntOrPercent(&intstr.IntOrString{Type: intstr.String, StrVal: value}, len(gang), true); err == nil {
-			if intVal < 1 {
-				return len(gang)
-			}
-			if intVal > len(gang) {
-				return len(gang)
-			}
-			return intVal
+// recordFailedScheduling emits a FailedScheduling event carrying err's
+// message, the reason a later scheduling attempt or `kubectl describe pod`
+// would show for why pod wasn't bound this cycle.
+func (s *BatchScheduler) recordFailedScheduling(pod *v1.Pod, err error) {
+	s.recorder.Eventf(pod, nil, v1.EventTypeWarning, "FailedScheduling", "Scheduling", "%v", err)
+}
+
+// oldestCreationTimestamp returns the earliest CreationTimestamp among
+// pods, used to measure how long a gang waited for enough members to
+// become ready.
+func oldestCreationTimestamp(pods []*v1.Pod) time.Time {
+	oldest := pods[0].CreationTimestamp.Time
+	for _, p := range pods[1:] {
+		if p.CreationTimestamp.Time.Before(oldest) {
+			oldest = p.CreationTimestamp.Time
 		}
 	}
-	return len(gang)
+	return oldest
 }
 
-func (s *BatchScheduler) podsForGang(namespace, gangID string) ([]*v1.Pod, error) {
-	selector := labels.Set{s.gangLabel: gangID}.AsSelector()
-	pods, err := s.podInformer.Lister().Pods(namespace).List(selector)
+// planGang runs the framework's PreFilter/Filter/Score/Reserve extension
+// points for every pod in the gang, in order, then Permit/PreBind for the
+// whole gang at once, and returns the node chosen for each, in the same
+// order as pods. Unlike the old greedy first-fit loop, node selection now
+// goes through whatever plugins the active Profile enables.
+func (s *BatchScheduler) planGang(ctx context.Context, pods []*v1.Pod) ([]string, error) {
+	nodeInfos, err := (*nodeInfoLister)(s).List()
 	if err != nil {
 		return nil, err
 	}
-	// ensure scheduler matches
-	filtered := make([]*v1.Pod, 0, len(pods))
-	for _, p := range pods {
-		if p.Spec.SchedulerName == s.schedulerName {
-			filtered = append(filtered, p)
+	if len(nodeInfos) == 0 {
+		return nil, fmt.Errorf("no schedulable nodes")
+	}
+	byName := make(map[string]*framework.NodeInfo, len(nodeInfos))
+	for _, ni := range nodeInfos {
+		byName[ni.Node.Name] = ni
+	}
+
+	plan := make([]string, len(pods))
+	states := make([]*framework.CycleState, len(pods))
+	for i, pod := range pods {
+		state := framework.NewCycleState()
+		states[i] = state
+		if status := s.framework.RunPreFilterPlugins(ctx, state, pod); !status.IsSuccess() {
+			return nil, fmt.Errorf("prefilter pod %s: %s", pod.Name, status.Message())
+		}
+
+		feasible := make([]*framework.NodeInfo, 0, len(byName))
+		for _, ni := range byName {
+			if status := s.framework.RunFilterPlugins(ctx, state, pod, ni); status.IsSuccess() {
+				feasible = append(feasible, ni)
+			}
 		}
+		if len(feasible) == 0 {
+			return nil, &errNoFeasibleNode{pod: pod}
+		}
+
+		scores, status := s.framework.RunScorePlugins(ctx, state, pod, feasible)
+		if !status.IsSuccess() {
+			return nil, fmt.Errorf("score pod %s: %s", pod.Name, status.Message())
+		}
+		chosen := bestScoringNode(feasible, scores)
+
+		if status := s.framework.RunReservePlugins(ctx, state, pod, chosen); !status.IsSuccess() {
+			return nil, fmt.Errorf("reserve pod %s on %s: %s", pod.Name, chosen, status.Message())
+		}
+
+		byName[chosen].AddPod(pod)
+		plan[i] = chosen
 	}
-	return filtered, nil
+
+	if err := s.runPermitAndPreBind(ctx, pods, states, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
 }
 
-func filterUnboundPods(pods []*v1.Pod) []*v1.Pod {
-	res := make([]*v1.Pod, 0, len(pods))
-	for _, p := range pods {
-		if p.Spec.NodeName == "" && p.DeletionTimestamp == nil {
-			res = append(res, p)
+// runPermitAndPreBind runs Permit and PreBind for every pod in the gang
+// concurrently, one goroutine per pod. A Permit plugin like Coscheduling
+// blocks until every gang member has reserved a node; waiting on them one
+// at a time on a single goroutine would deadlock any gang with
+// MinMember > 1, since later pods would never get a chance to reserve
+// while an earlier pod's Permit call is still waiting.
+func (s *BatchScheduler) runPermitAndPreBind(ctx context.Context, pods []*v1.Pod, states []*framework.CycleState, plan []string) error {
+	errs := make([]error, len(pods))
+	var wg sync.WaitGroup
+	wg.Add(len(pods))
+	for i := range pods {
+		i := i
+		go func() {
+			defer wg.Done()
+			pod, state, nodeName := pods[i], states[i], plan[i]
+			if status := s.framework.RunPermitPlugins(ctx, state, pod, nodeName); !status.IsSuccess() {
+				s.framework.RunUnreservePlugins(ctx, state, pod, nodeName)
+				errs[i] = fmt.Errorf("permit pod %s on %s: %s", pod.Name, nodeName, status.Message())
+				return
+			}
+			if status := s.framework.RunPreBindPlugins(ctx, state, pod, nodeName); !status.IsSuccess() {
+				s.framework.RunUnreservePlugins(ctx, state, pod, nodeName)
+				errs[i] = fmt.Errorf("prebind pod %s on %s: %s", pod.Name, nodeName, status.Message())
+				return
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
 	}
-	return res
+	return nil
 }
 
-func (s *BatchScheduler) planGang(ctx context.Context, pods []*v1.Pod) ([]string, error) {
-	nodes, err := s.nodeInformer.Lister().List(labels.Everything())
+// bestScoringNode picks the feasible node with the highest combined score,
+// breaking ties by node name so results stay deterministic.
+func bestScoringNode(feasible []*framework.NodeInfo, scores map[string]int64) string {
+	best := feasible[0].Node.Name
+	bestScore := scores[best]
+	for _, ni := range feasible[1:] {
+		if s := scores[ni.Node.Name]; s > bestScore || (s == bestScore && ni.Node.Name < best) {
+			best = ni.Node.Name
+			bestScore = s
+		}
+	}
+	return best
+}
+
+// errNoFeasibleNode distinguishes "no node fits this pod" from every other
+// planGang failure so schedulePod knows when a preemption pass is worth
+// attempting.
+type errNoFeasibleNode struct {
+	pod *v1.Pod
+}
+
+func (e *errNoFeasibleNode) Error() string {
+	return fmt.Sprintf("no feasible node for pod %s", e.pod.Name)
+}
+
+// preemptForGang runs a preemption pass over pods: for each pod, in order,
+// it finds the node whose simulated victim set (after evicting lower-priority
+// pods, skipping any protected by a PodDisruptionBudget) would make the pod
+// fit, consuming that capacity before considering the next pod so the whole
+// gang is only committed to if every member can be made feasible atomically.
+// If any pod cannot be made to fit on any node, no evictions happen at all
+// and the gang is requeued to retry later. Otherwise every chosen victim is
+// evicted and every preempting pod is nominated for its node; binding itself
+// happens on a later scheduling cycle once the victims are actually gone.
+func (s *BatchScheduler) preemptForGang(ctx context.Context, pods []*v1.Pod) error {
+	nodeInfos, err := (*nodeInfoLister)(s).List()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	// compute available resources for each node
-	avail := make(map[string]resourceState, len(nodes))
-	for _, n := range nodes {
-		if !nodeReady(n) || n.Spec.Unschedulable {
-			continue
-		}
-		avail[n.Name] = s.availableResources(n)
+	byName := make(map[string]*framework.NodeInfo, len(nodeInfos))
+	for _, ni := range nodeInfos {
+		byName[ni.Node.Name] = ni
 	}
-	if len(avail) == 0 {
-		return nil, fmt.Errorf("no schedulable nodes")
+
+	type plannedPreemption struct {
+		pod       *v1.Pod
+		candidate *preemption.Candidate
 	}
+	plans := make([]plannedPreemption, 0, len(pods))
 
-	plan := make([]string, len(pods))
-	for i, p := range pods {
-		bestNode := ""
-		for nodeName, state := range avail {
-			if state.canFit(p) {
-				bestNode = nodeName
-				// optimistic allocate resources
-				state.consume(p)
-				avail[nodeName] = state
-				break
+	for _, pod := range pods {
+		var candidates []*preemption.Candidate
+		for _, ni := range byName {
+			candidate, err := s.preemptor.FindVictims(pod, ni)
+			if err != nil {
+				return err
+			}
+			if candidate != nil {
+				candidates = append(candidates, candidate)
 			}
 		}
-		if bestNode == "" {
-			return nil, fmt.Errorf("no feasible node for pod %s", p.Name)
+		best := preemption.BestCandidate(candidates)
+		if best == nil {
+			err := fmt.Errorf("gang cannot be made feasible for pod %s via preemption", pod.Name)
+			s.recordFailedScheduling(pod, err)
+			return err
 		}
-		plan[i] = bestNode
+		plans = append(plans, plannedPreemption{pod: pod, candidate: best})
+
+		ni := byName[best.NodeName]
+		ni.Pods = withoutVictims(ni.Pods, best.Victims)
+		ni.Requested = framework.Resource{}
+		for _, p := range ni.Pods {
+			req := framework.PodRequest(p)
+			ni.Requested.MilliCPU += req.MilliCPU
+			ni.Requested.Memory += req.Memory
+		}
+		ni.AddPod(pod)
 	}
-	return plan, nil
-}
 
-func (s *BatchScheduler) availableResources(node *v1.Node) resourceState {
-	alloc := node.Status.Allocatable
-	cpu := alloc.Cpu().MilliValue()
-	mem := alloc.Memory().Value()
+	logger := klog.FromContext(ctx)
+	victimCount := 0
+	for _, pl := range plans {
+		if err := s.preemptor.Evict(ctx, pl.candidate); err != nil {
+			return fmt.Errorf("evict victims for pod %s: %w", pl.pod.Name, err)
+		}
+		if err := s.preemptor.NominateNode(ctx, pl.pod, pl.candidate.NodeName); err != nil {
+			return fmt.Errorf("nominate node for pod %s: %w", pl.pod.Name, err)
+		}
+		logger.Info("preempted victims", "preemptingPod", pl.pod.Name, "node", pl.candidate.NodeName, "victims", len(pl.candidate.Victims))
+		s.recorder.Eventf(pl.pod, nil, v1.EventTypeNormal, "Preempted", "Preempting", "Preempted %d pod(s) on node %s to make room", len(pl.candidate.Victims), pl.candidate.NodeName)
+		for _, victim := range pl.candidate.Victims {
+			s.recorder.Eventf(victim, nil, v1.EventTypeWarning, "Preempted", "Preempting", "Preempted by pod %s to make room on node %s", pl.pod.Name, pl.candidate.NodeName)
+		}
+		victimCount += len(pl.candidate.Victims)
+	}
+	s.metrics.PreemptionVictims.Observe(float64(victimCount))
+	return fmt.Errorf("preempted %d pods to make room for gang, retrying once they terminate", victimCount)
+}
 
-	// subtract requests of running pods
-	pods, _ := s.podInformer.Lister().Pods(metav1.NamespaceAll).List(labels.Everything())
+// withoutVictims returns pods with every pod whose UID appears in victims removed.
+func withoutVictims(pods []*v1.Pod, victims []*v1.Pod) []*v1.Pod {
+	remove := make(map[types.UID]bool, len(victims))
+	for _, v := range victims {
+		remove[v.UID] = true
+	}
+	kept := make([]*v1.Pod, 0, len(pods))
 	for _, p := range pods {
-		if p.Spec.NodeName != node.Name {
-			continue
+		if !remove[p.UID] {
+			kept = append(kept, p)
 		}
-		req := calculateRequest(p)
-		cpu -= req.cpuMilli
-		mem -= req.memory
 	}
-	return resourceState{cpuMilli: cpu, memory: mem}
+	return kept
 }
 
-func nodeReady(node *v1.Node) bool {
-	for _, cond := range node.Status.Conditions {
-		if cond.Type == v1.NodeReady {
-			return cond.Status == v1.ConditionTrue
+func filterUnboundPods(pods []*v1.Pod) []*v1.Pod {
+	res := make([]*v1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if p.Spec.NodeName == "" && p.DeletionTimestamp == nil {
+			res = append(res, p)
 		}
 	}
-	return false
+	return res
 }
 
 func (s *BatchScheduler) bindPod(ctx context.Context, pod *v1.Pod, nodeName string) error {
-	binding := &v1.Binding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-			UID:       pod.UID,
-		},
-		Target: v1.ObjectReference{
-			Kind: "Node",
-			Name: nodeName,
-		},
-	}
+	state := framework.NewCycleState()
+	bindStart := time.Now()
+	status := s.framework.RunBindPlugins(ctx, state, pod, nodeName)
+	s.metrics.BindingDuration.Observe(time.Since(bindStart).Seconds())
+	if !status.IsSuccess() {
+		return fmt.Errorf("%s", status.Message())
+	}
+	// Reserve the pod's resources in the cache now rather than waiting for
+	// the pod informer to observe the binding, so the next scheduling cycle
+	// doesn't race ahead and double-book nodeName.
+	s.cache.AssumePod(pod, nodeName)
+	s.metrics.PodSchedulingSLIDuration.Observe(time.Since(pod.CreationTimestamp.Time).Seconds())
+	klog.FromContext(ctx).V(2).Info("bound pod", "node", nodeName)
+	return nil
+}
 
-	return s.client.CoreV1().Pods(pod.Namespace).Bind(ctx, binding, metav1.CreateOptions{})
+// nodeInfoLister adapts BatchScheduler to framework.NodeInfoLister by taking
+// a fresh copy-on-write snapshot of the scheduler cache on every call,
+// giving each scheduling cycle an O(1)-per-node read of resource state
+// instead of relisting every pod in the cluster.
+type nodeInfoLister BatchScheduler
+
+// List implements framework.NodeInfoLister.
+func (l *nodeInfoLister) List() ([]*framework.NodeInfo, error) {
+	return l.cache.Snapshot().List()
 }
 
-// resourceState tracks available CPU/memory in milliCPU and bytes.
-type resourceState struct {
-	cpuMilli int64
-	memory   int64
+// Get implements framework.NodeInfoLister.
+func (l *nodeInfoLister) Get(nodeName string) (*framework.NodeInfo, error) {
+	return l.cache.Snapshot().Get(nodeName)
 }
 
-func (r resourceState) canFit(pod *v1.Pod) bool {
-	req := calculateRequest(pod)
-	return r.cpuMilli >= req.cpuMilli && r.memory >= req.memory
+// pdbLister adapts BatchScheduler to preemption.PDBLister.
+type pdbLister BatchScheduler
+
+// List implements preemption.PDBLister.
+func (l *pdbLister) List() ([]*policyv1.PodDisruptionBudget, error) {
+	return l.pdbInformer.Lister().List(labels.Everything())
 }
 
-func (r *resourceState) consume(pod *v1.Pod) {
-	req := calculateRequest(pod)
-	r.cpuMilli -= req.cpuMilli
-	r.memory -= req.memory
+// gangResolver adapts BatchScheduler to framework.GangResolver, resolving a
+// pod's gang via its PodGroup CR (falling back to the legacy gang label,
+// and finally to treating the pod as a gang of one).
+type gangResolver BatchScheduler
+
+// ResolveGang implements framework.GangResolver.
+func (g *gangResolver) ResolveGang(pod *v1.Pod) (string, int, []*v1.Pod, error) {
+	gangID := pod.Labels[schedulingv1alpha1.PodGroupLabel]
+	if gangID == "" {
+		gangID = pod.Labels[g.gangLabel]
+	}
+	if gangID == "" {
+		return pod.Name, 1, []*v1.Pod{pod}, nil
+	}
+
+	members, err := g.podsForGang(pod.Namespace, gangID)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(members) == 0 {
+		return gangID, 1, nil, nil
+	}
+	return gangID, g.resolveMinAvailable(pod.Namespace, gangID, members), members, nil
 }
 
-// resourceDemand sums container requests.
-type resourceDemand struct {
-	cpuMilli int64
-	memory   int64
+// resolveMinAvailable looks up the PodGroup named gangID and returns its
+// MinMember, falling back to requiring every pod in the gang when no
+// PodGroup exists (e.g. a single pod scheduled without one) or its
+// MinMember is below 1. A MinMember above the current member count is
+// returned as is, so the gang waits for more members.
+func (g *gangResolver) resolveMinAvailable(namespace, gangID string, gang []*v1.Pod) int {
+	if len(gang) == 0 {
+		return 0
+	}
+	pg, err := g.podGroupLister.PodGroups(namespace).Get(gangID)
+	if err != nil {
+		return len(gang)
+	}
+	minMember := int(pg.Spec.MinMember)
+	if minMember < 1 {
+		return len(gang)
+	}
+	return minMember
 }
 
-func calculateRequest(pod *v1.Pod) resourceDemand {
-	var cpu, mem int64
-	for _, c := range pod.Spec.Containers {
-		cpu += c.Resources.Requests.Cpu().MilliValue()
-		mem += c.Resources.Requests.Memory().Value()
+func (g *gangResolver) podsForGang(namespace, gangID string) ([]*v1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{schedulingv1alpha1.PodGroupLabel: gangID})
+	pods, err := g.podInformer.Lister().Pods(namespace).List(selector)
+	if err != nil {
+		return nil, err
 	}
-	return resourceDemand{cpuMilli: cpu, memory: mem}
+	if len(pods) == 0 {
+		selector = labels.Set{g.gangLabel: gangID}.AsSelector()
+		pods, err = g.podInformer.Lister().Pods(namespace).List(selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filtered := make([]*v1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if p.Spec.SchedulerName == g.schedulerName {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
 }